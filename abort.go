@@ -0,0 +1,70 @@
+package terminator
+
+import "context"
+
+// Abort abandons the termination in progress, for cases where continuing to
+// drain is pointless, such as a node being preempted in seconds. The contexts
+// of the running closers are canceled, including detached ones, and the
+// resources not closed yet are skipped, except for the finalizers registered
+// in PhaseFinalizer. The callback still runs, and the termination completes
+// in StateAborted with TerminationResult.Aborted set.
+// It reports false if the termination is not draining or closing resources.
+func (t *terminator) Abort() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.aborted || (t.state != StateDraining && t.state != StateClosing) {
+		return false
+	}
+
+	t.aborted = true
+	close(t.abortChan)
+
+	return true
+}
+
+// isAborted reports whether Abort was called.
+func (t *terminator) isAborted() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.aborted
+}
+
+// abortable returns a context derived from parent that is canceled by Abort.
+func (t *terminator) abortable(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	go func() {
+		select {
+		case <-t.abortChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// closeOne closes a single resource, or skips it once the termination was
+// aborted, finalizers excepted, or outside of its environments.
+func (t *terminator) closeOne(ctx context.Context, closer *payload) TerminationResultData {
+	t.awaitResume(closer.Name)
+
+	if !t.config.enabled(closer) || (!closer.isFinalizer() && t.isAborted()) {
+		return TerminationResultData{Name: closer.Name, Status: SKIPPED, Detached: closer.Detached}
+	}
+
+	adjusted := t.adjustTimeout(*closer)
+
+	ctx, cancel := t.budgetContext(ctx)
+	defer cancel()
+
+	termData := <-t.closeStack(ctx, &adjusted, true)
+	if termData.Error != nil && !closer.isFinalizer() && t.isAborted() {
+		termData.Status = ABORTED
+	}
+	t.lintInstantClose(closer, termData)
+
+	return termData
+}
@@ -0,0 +1,111 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAbort(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	if term.Abort() {
+		t.Error("Abort shouldn't succeed before the termination started")
+	}
+
+	started := make(chan struct{})
+	var finalized, dbClosed, detachedCanceled bool
+
+	term.AddWithOptions("audit log", func(ctx context.Context) error {
+		finalized = ctx.Err() == nil
+		return nil
+	}, InPhase(PhaseFinalizer))
+	term.Add("db", func(ctx context.Context) error {
+		dbClosed = true
+		return nil
+	})
+	term.AddWithOptions("transaction", func(ctx context.Context) error {
+		<-ctx.Done()
+		detachedCanceled = true
+		return ctx.Err()
+	}, WithDetachedContext(time.Minute), InPhase(PhaseCommit))
+	term.AddWithOptions("server", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, InPhase(PhaseServer))
+
+	var callbackResult TerminationResult
+	term.SetCallback(func(result TerminationResult) {
+		callbackResult = result
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	<-started
+
+	if !term.Abort() {
+		t.Fatal("Abort should succeed while closing")
+	}
+	if term.Abort() {
+		t.Error("Abort should succeed only once")
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if term.State() != StateAborted {
+		t.Errorf("Expected state %v, got %v", StateAborted, term.State())
+	}
+	if dbClosed || detachedCanceled {
+		t.Errorf("Expected the pending resources to be skipped, db closed: %v, transaction run: %v", dbClosed, detachedCanceled)
+	}
+	if !finalized {
+		t.Error("Expected the finalizer to run on a live context")
+	}
+
+	if !callbackResult.Aborted {
+		t.Error("Expected the callback to receive an aborted result")
+	}
+
+	expected := map[string]TerminationStatus{
+		"server":      ABORTED,
+		"transaction": SKIPPED,
+		"db":          SKIPPED,
+		"audit log":   SUCCESS,
+	}
+	for _, termData := range callbackResult.Result {
+		if termData.Status != expected[termData.Name] {
+			t.Errorf("%s: expected status %v, got %v", termData.Name, expected[termData.Name], termData.Status)
+		}
+	}
+
+	if status := term.Status(); !status.Done || status.Progress != 1 {
+		t.Errorf("Expected an aborted termination to be done, got %+v", status)
+	}
+}
+
+func TestAbortCancelsDetachedClosers(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	started := make(chan struct{})
+	term.AddWithOptions("transaction", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithDetachedContext(time.Minute))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	<-started
+	term.Abort()
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Abort should cancel detached closers")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Status != ABORTED || result.FailedOrTimeoutCount != 1 {
+		t.Errorf("Expected the transaction to be aborted, got %+v", result)
+	}
+}
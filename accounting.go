@@ -0,0 +1,43 @@
+package terminator
+
+import "runtime"
+
+// ResourceUsage is a rough measure of the resources consumed while closing
+// a resource. It is sampled process-wide, so it includes the activity of
+// every other goroutine running at the same time.
+type ResourceUsage struct {
+
+	// Change in the number of goroutines
+	GoroutineDelta int
+
+	// Number of heap objects allocated
+	Mallocs uint64
+
+	// Bytes allocated for heap objects
+	TotalAlloc uint64
+}
+
+// usageSample is a snapshot of the process resources.
+type usageSample struct {
+	goroutines int
+	memStats   runtime.MemStats
+}
+
+// sampleUsage takes a snapshot of the process resources.
+func sampleUsage() *usageSample {
+	sample := &usageSample{goroutines: runtime.NumGoroutine()}
+	runtime.ReadMemStats(&sample.memStats)
+
+	return sample
+}
+
+// since returns the resources consumed since the sample was taken.
+func (sample *usageSample) since() *ResourceUsage {
+	now := sampleUsage()
+
+	return &ResourceUsage{
+		GoroutineDelta: now.goroutines - sample.goroutines,
+		Mallocs:        now.memStats.Mallocs - sample.memStats.Mallocs,
+		TotalAlloc:     now.memStats.TotalAlloc - sample.memStats.TotalAlloc,
+	}
+}
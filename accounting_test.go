@@ -0,0 +1,63 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResourceAccounting(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithResourceAccounting())
+
+	var leaked chan struct{}
+	term.Add("leaky", func(ctx context.Context) error {
+		// Goroutines of other tests may exit meanwhile, leak enough of them
+		// for the delta to stay positive.
+		leaked = make(chan struct{})
+		for i := 0; i < 100; i++ {
+			go func() { <-leaked }()
+		}
+
+		buffers := make([][]byte, 0, 100)
+		for i := 0; i < 100; i++ {
+			buffers = append(buffers, make([]byte, 1024))
+		}
+		_ = buffers
+
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+	close(leaked)
+
+	data := result.Result[0]
+	if data.Duration < 20*time.Millisecond {
+		t.Errorf("Expected a duration of at least 20ms, got %v", data.Duration)
+	}
+
+	if data.Usage == nil {
+		t.Error("Usage should be recorded")
+		return
+	}
+
+	if data.Usage.GoroutineDelta < 1 {
+		t.Errorf("Expected the leaked goroutines to be counted, got %d", data.Usage.GoroutineDelta)
+	}
+
+	if data.Usage.TotalAlloc < 100*1024 {
+		t.Errorf("Expected at least 100KiB allocated, got %d", data.Usage.TotalAlloc)
+	}
+}
@@ -0,0 +1,80 @@
+package terminator
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// afterShutdownFunc is a function registered with AfterShutdown, run at most once.
+type afterShutdownFunc struct {
+	fn      func()
+	started uint32
+}
+
+// AfterShutdown arranges for fn to run once the closers stack of term is
+// closed, before the callback, in the fashion of context.AfterFunc: a cheap
+// tier for tiny cleanups that take no context, return no error and do not
+// appear in the result. The functions run one after another, the latest
+// registered first, and their panics are recovered and logged. If the stack
+// is already closed, fn runs right away in its own goroutine.
+//
+// Calling the returned stop function prevents fn from running. It reports
+// true if it did, false if fn already started or was stopped. Terminators
+// of other packages get fn registered as a finalizer instead, which stop
+// cannot remove, and the error of the registration is returned.
+func AfterShutdown(term Terminator, fn func()) (stop func() bool, err error) {
+	t, ok := term.(*terminator)
+	if !ok {
+		_, err := term.AddWithOptions("after shutdown", func(ctx context.Context) error {
+			fn()
+			return nil
+		}, InPhase(PhaseFinalizer))
+		return func() bool { return false }, err
+	}
+
+	after := &afterShutdownFunc{fn: fn}
+	stop = func() bool {
+		return atomic.CompareAndSwapUint32(&after.started, 0, 1)
+	}
+
+	t.mu.Lock()
+	ran := t.afterShutdownRan
+	if !ran {
+		t.afterShutdown = append(t.afterShutdown, after)
+	}
+	t.mu.Unlock()
+
+	if ran {
+		go t.runAfterShutdown(after)
+	}
+
+	return stop, nil
+}
+
+// runAfterShutdowns runs the functions registered with AfterShutdown, the
+// latest registered first.
+func (t *terminator) runAfterShutdowns() {
+	t.mu.Lock()
+	afters := t.afterShutdown
+	t.afterShutdown = nil
+	t.afterShutdownRan = true
+	t.mu.Unlock()
+
+	for index := len(afters) - 1; index >= 0; index-- {
+		t.runAfterShutdown(afters[index])
+	}
+}
+
+// runAfterShutdown runs the function unless stopped, recovering its panic.
+func (t *terminator) runAfterShutdown(after *afterShutdownFunc) {
+	if !atomic.CompareAndSwapUint32(&after.started, 0, 1) {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.config.logger.Printf("function run after the shutdown panicked: %v", r)
+		}
+	}()
+	after.fn()
+}
@@ -0,0 +1,59 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAfterShutdown(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}))
+
+	var order []string
+	term.Add("db", func(ctx context.Context) error {
+		order = append(order, "db")
+		return nil
+	})
+	AfterShutdown(term, func() { order = append(order, "first") })
+	AfterShutdown(term, func() { panic("boom") })
+	AfterShutdown(term, func() { order = append(order, "last") })
+	stop, err := AfterShutdown(term, func() { order = append(order, "stopped") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	term.SetCallback(func(result TerminationResult) { order = append(order, "callback") })
+
+	if !stop() || stop() {
+		t.Error("Expected stop to report true only the first time")
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	expected := []string{"db", "last", "first", "callback"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for index := range expected {
+		if order[index] != expected[index] {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 1 {
+		t.Errorf("Expected the functions not to appear in the result, got %+v", result.Result)
+	}
+
+	ran := make(chan struct{})
+	AfterShutdown(term, func() { close(ran) })
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("Expected a function registered after the shutdown to run right away")
+	}
+}
@@ -0,0 +1,75 @@
+package terminator
+
+import "time"
+
+// PhaseAnnounce runs the announcers, telling upstreams that the service is
+// draining, before any resource is closed.
+const PhaseAnnounce Phase = -700
+
+// defaultAnnounceTimeout is how long every announcer has by default.
+const defaultAnnounceTimeout = 5 * time.Second
+
+// announcer is an announce hook registered with WithAnnouncer.
+type announcer struct {
+	name     string
+	announce CloseFunc
+}
+
+// WithAnnouncer adds a hook run in PhaseAnnounce, first thing once the
+// termination starts, to announce the draining to upstreams: deregistering
+// from a service registry or reporting NOT_READY to a mesh. The announce
+// package provides adapters for common registries. Announcers do not count
+// as registered resources while the terminator waits for registrations.
+func WithAnnouncer(name string, announce CloseFunc) Option {
+	return func(c *config) {
+		c.announcers = append(c.announcers, announcer{name: name, announce: announce})
+	}
+}
+
+// WithAnnounceTimeout sets the timeout of every announcer, 5 seconds by
+// default, so that an unreachable registry does not hold up the shutdown.
+func WithAnnounceTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.announceTimeout = timeout
+	}
+}
+
+// pushAnnouncers adds the announcers to the closers stack.
+func (t *terminator) pushAnnouncers() {
+	for _, a := range t.config.announcers {
+		t.pushHook(payload{
+			Name:    a.name,
+			Close:   a.announce,
+			Timeout: t.config.announceTimeout,
+			Phase:   PhaseAnnounce,
+		})
+	}
+}
+
+// pushHook adds a closer configured through the options to the closers
+// stack. Unlike push, it does not record a registration. Hooks are pushed
+// when the terminator is created, before any registration.
+func (t *terminator) pushHook(closer payload) {
+	t.nextID++
+	closer.id = t.nextID
+	t.positions[closer.id] = len(t.closersStack)
+	t.closersStack = append(t.closersStack, closer)
+	t.hooks++
+}
+
+// isHookLocked reports whether the closer was configured through the
+// options, as hooks hold the first ids. It must be called with t.mu held.
+func (t *terminator) isHookLocked(closer *payload) bool {
+	return closer.id <= uint64(t.hooks)
+}
+
+// announceLatency returns the time from the termination signal until the
+// announce phase completed, given the time waited before closing, or zero
+// if the phase did not run.
+func announceLatency(wait time.Duration, phases []PhaseReport) time.Duration {
+	if len(phases) == 0 || phases[0].Phase != PhaseAnnounce {
+		return 0
+	}
+
+	return wait + phases[0].Duration
+}
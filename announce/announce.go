@@ -0,0 +1,159 @@
+// Package announce provides announcers for terminator.WithAnnouncer, telling
+// common service registries that the service is draining before any resource
+// is closed. They only depend on the HTTP APIs of the registries.
+//
+// Deregistration gates the safe draining of connections: the time it took
+// is reported in the AnnounceLatency of the termination result.
+package announce
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// HTTP returns an announcer sending a request with method to rawURL, with
+// client or http.DefaultClient if nil, such as a NOT_READY notification to a
+// service mesh. Responses other than 2xx are reported as errors.
+func HTTP(method, rawURL string, client *http.Client) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		return send(ctx, client, method, rawURL, nil)
+	}
+}
+
+// Consul returns an announcer deregistering the service serviceID from the
+// local Consul agent at addr, such as http://127.0.0.1:8500.
+func Consul(addr, serviceID string, client *http.Client) terminator.CloseFunc {
+	endpoint := strings.TrimSuffix(addr, "/") + "/v1/agent/service/deregister/" + url.PathEscape(serviceID)
+
+	return HTTP(http.MethodPut, endpoint, client)
+}
+
+// Eureka returns an announcer taking the instance instanceID of the
+// application app out of service in the Eureka server at serviceURL, such as
+// http://eureka:8761/eureka, so that clients stop discovering it.
+func Eureka(serviceURL, app, instanceID string, client *http.Client) terminator.CloseFunc {
+	endpoint := strings.TrimSuffix(serviceURL, "/") + "/apps/" + url.PathEscape(app) + "/" + url.PathEscape(instanceID) + "/status?value=OUT_OF_SERVICE"
+
+	return HTTP(http.MethodPut, endpoint, client)
+}
+
+// Etcd returns an announcer deleting the registration key from etcd through
+// the JSON gateway of the v3 API at endpoint, such as http://127.0.0.1:2379.
+func Etcd(endpoint, key string, client *http.Client) terminator.CloseFunc {
+	rawURL := strings.TrimSuffix(endpoint, "/") + "/v3/kv/deleterange"
+
+	return func(ctx context.Context) error {
+		body, err := json.Marshal(map[string]string{
+			"key": base64.StdEncoding.EncodeToString([]byte(key)),
+		})
+		if err != nil {
+			return err
+		}
+
+		return send(ctx, client, http.MethodPost, rawURL, body)
+	}
+}
+
+// EtcdLease returns an announcer revoking the lease leaseID through the JSON
+// gateway of the etcd v3 API at endpoint, deleting at once every key the
+// instance registered with it.
+func EtcdLease(endpoint string, leaseID int64, client *http.Client) terminator.CloseFunc {
+	rawURL := strings.TrimSuffix(endpoint, "/") + "/v3/lease/revoke"
+
+	return func(ctx context.Context) error {
+		body, err := json.Marshal(map[string]string{
+			"ID": strconv.FormatInt(leaseID, 10),
+		})
+		if err != nil {
+			return err
+		}
+
+		return send(ctx, client, http.MethodPost, rawURL, body)
+	}
+}
+
+// Default retries of the sidecar drain announcers.
+const (
+	defaultDrainAttempts = 3
+	defaultDrainBackoff  = 200 * time.Millisecond
+)
+
+// Envoy returns an announcer asking the local Envoy sidecar, whose admin
+// interface is at adminURL such as http://127.0.0.1:9901, to drain its
+// listeners gracefully, so that it stops routing to the service before the
+// listeners of the application are closed. It is retried on failure, as the
+// sidecar may be busy at the start of the shutdown.
+func Envoy(adminURL string, client *http.Client) terminator.CloseFunc {
+	return Drain(strings.TrimSuffix(adminURL, "/")+"/drain_listeners?graceful", client)
+}
+
+// Drain returns an announcer posting to the drain URL of a mesh sidecar,
+// retried on failure like Envoy.
+func Drain(rawURL string, client *http.Client) terminator.CloseFunc {
+	return Retry(HTTP(http.MethodPost, rawURL, client), defaultDrainAttempts, defaultDrainBackoff)
+}
+
+// Retry returns an announcer calling announce up to attempts times, waiting
+// backoff between them, until it succeeds or ctx is done. It returns the
+// last error.
+func Retry(announce terminator.CloseFunc, attempts int, backoff time.Duration) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		var err error
+		for attempt := 1; ; attempt++ {
+			if err = announce(ctx); err == nil || attempt >= attempts {
+				return err
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// send sends the request and reports responses other than 2xx as errors.
+func send(ctx context.Context, client *http.Client, method, rawURL string, body []byte) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("announce: unexpected response status %s from %s", resp.Status, req.URL.Host)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package announce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// request is a request received by the test registry.
+type request struct {
+	method string
+	uri    string
+	body   string
+}
+
+// newRegistry returns a test registry answering with status and the requests it received.
+func newRegistry(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, request{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestAdapters(t *testing.T) {
+	server, requests := newRegistry(t, http.StatusOK)
+
+	announcers := []func(context.Context) error{
+		Consul(server.URL+"/", "api-1", nil),
+		Eureka(server.URL+"/eureka", "API", "api-1", nil),
+		HTTP(http.MethodPost, server.URL+"/not-ready", server.Client()),
+	}
+	for _, announce := range announcers {
+		if err := announce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []request{
+		{method: http.MethodPut, uri: "/v1/agent/service/deregister/api-1"},
+		{method: http.MethodPut, uri: "/eureka/apps/API/api-1/status?value=OUT_OF_SERVICE"},
+		{method: http.MethodPost, uri: "/not-ready"},
+	}
+	if len(*requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %+v", len(expected), *requests)
+	}
+	for i := range expected {
+		if (*requests)[i] != expected[i] {
+			t.Errorf("Request %d: expected %+v, got %+v", i, expected[i], (*requests)[i])
+		}
+	}
+}
+
+func TestEtcd(t *testing.T) {
+	server, requests := newRegistry(t, http.StatusOK)
+
+	if err := Etcd(server.URL, "/services/api/1", nil)(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*requests) != 1 || (*requests)[0].uri != "/v3/kv/deleterange" {
+		t.Fatalf("Unexpected requests: %+v", *requests)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal([]byte((*requests)[0].body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["key"] != "L3NlcnZpY2VzL2FwaS8x" {
+		t.Errorf("Expected the base64 encoded key, got %q", body["key"])
+	}
+}
+
+func TestUnexpectedStatus(t *testing.T) {
+	server, _ := newRegistry(t, http.StatusServiceUnavailable)
+
+	if err := Consul(server.URL, "api-1", nil)(context.Background()); err == nil {
+		t.Error("Expected an error for a 503 response")
+	}
+}
+
+func TestEtcdLease(t *testing.T) {
+	server, requests := newRegistry(t, http.StatusOK)
+
+	if err := EtcdLease(server.URL, 7587862072907246106, nil)(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := request{method: http.MethodPost, uri: "/v3/lease/revoke", body: `{"ID":"7587862072907246106"}`}
+	if len(*requests) != 1 || (*requests)[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *requests)
+	}
+}
+
+func TestEnvoyRetries(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.RequestURI() != "/drain_listeners?graceful" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.RequestURI())
+		}
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	if err := Envoy(server.URL+"/", nil)(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int
+	failing := func(ctx context.Context) error {
+		calls++
+		return errors.New("unreachable")
+	}
+
+	if err := Retry(failing, 3, time.Millisecond)(context.Background()); err == nil {
+		t.Error("Expected the last error")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	Retry(failing, 3, time.Hour)(ctx)
+	if calls != 1 {
+		t.Errorf("Expected a canceled context to stop the retries, got %d attempts", calls)
+	}
+}
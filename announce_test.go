@@ -0,0 +1,107 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAnnouncersRunFirst(t *testing.T) {
+	var order []string
+	announce := func(ctx context.Context) error {
+		order = append(order, "registry")
+		return nil
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithAnnouncer("registry", announce))
+	term.AddWithOptions("ticker", func(ctx context.Context) error {
+		order = append(order, "ticker")
+		return nil
+	}, InPhase(PhaseBackground))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(order) != 2 || order[0] != "registry" {
+		t.Errorf("Expected the announcer to run first, got %v", order)
+	}
+}
+
+func TestAnnounceTimeout(t *testing.T) {
+	announce := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithAnnouncer("registry", announce), WithAnnounceTimeout(20*time.Millisecond))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("An unreachable registry shouldn't hold up the shutdown")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Name != "registry" || result.Result[0].Status != FAILED || result.Result[0].Timeout != 20*time.Millisecond {
+		t.Errorf("Unexpected announcer result: %+v", result.Result[0])
+	}
+}
+
+func TestAnnouncersAreNotRegistrations(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(300*time.Millisecond),
+		WithAnnouncer("registry", func(ctx context.Context) error { return nil }))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	time.Sleep(150 * time.Millisecond)
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 2 {
+		t.Errorf("Expected the late registration to be closed, got %+v", result.Result)
+	}
+}
+
+func TestAnnounceLatency(t *testing.T) {
+	announce := func(ctx context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithAnnouncer("registry", announce))
+	term.Add("db", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.AnnounceLatency < 30*time.Millisecond || result.AnnounceLatency >= result.SLO.Duration {
+		t.Errorf("Expected the latency of the announcers only, got %v of %v", result.AnnounceLatency, result.SLO.Duration)
+	}
+}
+
+func TestAnnounceLatencyWithoutAnnouncers(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if result, _ := term.Result(); result.AnnounceLatency != 0 {
+		t.Errorf("Expected no latency without announcers, got %v", result.AnnounceLatency)
+	}
+}
@@ -0,0 +1,81 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+)
+
+// Barrier coordinates the teardown of a fixed number of symmetric
+// participants, such as worker goroutines. When its closer runs, the
+// ShuttingDown channel is closed and the closer completes once every
+// participant called Arrive.
+type Barrier struct {
+	mu        sync.Mutex
+	remaining int
+
+	shutdownChan chan struct{}
+	shutdownOnce sync.Once
+	arrivedChan  chan struct{}
+}
+
+// newBarrier creates a barrier waiting for n participants.
+func newBarrier(n int) *Barrier {
+	b := &Barrier{
+		remaining:    n,
+		shutdownChan: make(chan struct{}),
+		arrivedChan:  make(chan struct{}),
+	}
+
+	if n <= 0 {
+		close(b.arrivedChan)
+	}
+
+	return b
+}
+
+// Barrier registers a barrier waiting for n participants as a resource. It
+// returns the error of the registration, if any.
+func (t *terminator) Barrier(name string, n int, opts ...CloserOption) (*Barrier, error) {
+	b := newBarrier(n)
+	if _, err := t.AddWithOptions(name, b.close, opts...); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ShuttingDown returns a channel closed when the barrier's closer starts,
+// telling the participants to tear down and call Arrive.
+func (b *Barrier) ShuttingDown() <-chan struct{} {
+	return b.shutdownChan
+}
+
+// Arrive marks one participant as done. Participants exiting before the
+// termination may call it early. Calls beyond n participants are ignored.
+func (b *Barrier) Arrive() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining == 0 {
+		return
+	}
+
+	b.remaining--
+	if b.remaining == 0 {
+		close(b.arrivedChan)
+	}
+}
+
+// close signals the participants and waits for all of them to arrive.
+func (b *Barrier) close(ctx context.Context) error {
+	b.shutdownOnce.Do(func() {
+		close(b.shutdownChan)
+	})
+
+	select {
+	case <-b.arrivedChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
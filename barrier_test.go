@@ -0,0 +1,66 @@
+package terminator
+
+import (
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBarrier(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	barrier, err := term.Barrier("workers", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stopped int32
+	for i := 0; i < 3; i++ {
+		go func() {
+			<-barrier.ShuttingDown()
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt32(&stopped, 1)
+			barrier.Arrive()
+		}()
+	}
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if atomic.LoadInt32(&stopped) != 3 {
+		t.Errorf("All workers should have stopped, got %d", stopped)
+	}
+}
+
+func TestBarrierTimeout(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	barrier, err := term.Barrier("workers", 2, WithCloserTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	barrier.Arrive()
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if result.FailedOrTimeoutCount != 1 {
+		t.Error("Barrier should time out waiting for the missing participant")
+	}
+}
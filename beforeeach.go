@@ -0,0 +1,92 @@
+package terminator
+
+import "time"
+
+// CloserInfo describes a resource about to be closed, see WithBeforeEach.
+type CloserInfo struct {
+
+	// Name and phase of the resource
+	Name  string
+	Phase Phase
+
+	// Timeout the resource was registered with, zero if none
+	Timeout time.Duration
+
+	// Time elapsed since the termination signal
+	Elapsed time.Duration
+
+	// Number of resources not closed yet, including this one
+	Remaining int
+
+	// Sum of the timeouts of the resources not closed yet, including this one
+	RemainingTimeout time.Duration
+}
+
+// BeforeEachFunc returns the timeout to close the resource with, zero for none.
+type BeforeEachFunc func(info CloserInfo) time.Duration
+
+// WithBeforeEach calls hook just before every resource is closed, to adjust
+// its timeout, for instance from a reloaded configuration or when the
+// termination is behind schedule. The effective timeout is reported in the
+// result data. The hook is called concurrently with WithParallelPhases.
+func WithBeforeEach(hook BeforeEachFunc) Option {
+	return func(c *config) {
+		c.beforeEach = hook
+	}
+}
+
+// ProportionalTimeouts returns a hook for WithBeforeEach shrinking the
+// timeouts of the remaining resources proportionally when their sum exceeds
+// the time left until deadline, counted from the termination signal.
+// Timeouts are never extended, and resources without one are left as is.
+func ProportionalTimeouts(deadline time.Duration) BeforeEachFunc {
+	return func(info CloserInfo) time.Duration {
+		left := deadline - info.Elapsed
+		if info.Timeout <= 0 || info.RemainingTimeout <= left {
+			return info.Timeout
+		}
+
+		if left <= 0 {
+			return time.Nanosecond
+		}
+
+		return time.Duration(float64(info.Timeout) * float64(left) / float64(info.RemainingTimeout))
+	}
+}
+
+// remainingTimeouts returns the sums of the timeouts of closers from every
+// index on, computed once as the termination starts rather than for every
+// resource closed. The last sum, past the end, is zero.
+func remainingTimeouts(closers []payload) []time.Duration {
+	sums := make([]time.Duration, len(closers)+1)
+	for index := len(closers) - 1; index >= 0; index-- {
+		sums[index] = sums[index+1] + closers[index].Timeout
+	}
+
+	return sums
+}
+
+// adjustTimeout applies the BeforeEach hook, if any, to the closer about to run.
+func (t *terminator) adjustTimeout(closer payload) payload {
+	if t.config.beforeEach == nil {
+		return closer
+	}
+
+	t.mu.Lock()
+	info := CloserInfo{
+		Name:             closer.Name,
+		Phase:            closer.Phase,
+		Timeout:          closer.Timeout,
+		Elapsed:          time.Since(t.triggeredAt),
+		Remaining:        len(t.closing) - t.result.closed,
+		RemainingTimeout: t.remainingTimeouts[t.result.closed],
+	}
+	t.mu.Unlock()
+
+	var timeout time.Duration
+	if t.runHook(HookBeforeEach, func() { timeout = t.config.beforeEach(info) }) {
+		closer.Timeout = timeout
+	}
+
+	return closer
+}
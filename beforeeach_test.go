@@ -0,0 +1,62 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWithBeforeEach(t *testing.T) {
+	var infos []CloserInfo
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithBeforeEach(func(info CloserInfo) time.Duration {
+		infos = append(infos, info)
+		if info.Name == "db" {
+			return 10 * time.Millisecond
+		}
+		return info.Timeout
+	}))
+
+	term.AddWithTimeout("db", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, time.Minute)
+	term.AddWithTimeout("server", func(ctx context.Context) error { return nil }, 2*time.Second)
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The adjusted timeout should apply")
+	}
+
+	if len(infos) != 2 || infos[0].Name != "server" || infos[0].Remaining != 2 || infos[0].RemainingTimeout != time.Minute+2*time.Second {
+		t.Errorf("Unexpected hook calls %+v", infos)
+	}
+	if len(infos) == 2 && (infos[1].Remaining != 1 || infos[1].RemainingTimeout != time.Minute) {
+		t.Errorf("Unexpected hook call for db %+v", infos[1])
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Timeout != 2*time.Second || result.Result[1].Timeout != 10*time.Millisecond || result.Result[1].Kind != ErrorKindTimeout {
+		t.Errorf("Expected the effective timeouts in the result, got %+v", result.Result)
+	}
+}
+
+func TestProportionalTimeouts(t *testing.T) {
+	hook := ProportionalTimeouts(10 * time.Second)
+
+	cases := []struct {
+		info     CloserInfo
+		expected time.Duration
+	}{
+		{CloserInfo{Timeout: 4 * time.Second, RemainingTimeout: 8 * time.Second}, 4 * time.Second},
+		{CloserInfo{Timeout: 4 * time.Second, Elapsed: 6 * time.Second, RemainingTimeout: 8 * time.Second}, 2 * time.Second},
+		{CloserInfo{Timeout: 4 * time.Second, Elapsed: 12 * time.Second, RemainingTimeout: 8 * time.Second}, time.Nanosecond},
+		{CloserInfo{Elapsed: 12 * time.Second, RemainingTimeout: 8 * time.Second}, 0},
+	}
+
+	for i, c := range cases {
+		if got := hook(c.info); got != c.expected {
+			t.Errorf("Case %d: expected %v, got %v", i, c.expected, got)
+		}
+	}
+}
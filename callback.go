@@ -0,0 +1,19 @@
+package terminator
+
+// inCallback reports whether the callback is running. The completion of the
+// termination waits for the callback, so a Wait called meanwhile, such as
+// from the callback itself or from a goroutine it started, waits for the
+// resources to be closed only.
+func (t *terminator) inCallback() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.callbackRunning
+}
+
+// setCallbackRunning records whether the callback is running.
+func (t *terminator) setCallbackRunning(running bool) {
+	t.mu.Lock()
+	t.callbackRunning = running
+	t.mu.Unlock()
+}
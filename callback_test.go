@@ -0,0 +1,91 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCallbackBeforeWait(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	returned := false
+	term.SetCallback(func(result TerminationResult) {
+		time.Sleep(20 * time.Millisecond)
+		returned = true
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+	if !returned {
+		t.Error("Expected Wait to return once the callback returned")
+	}
+}
+
+func TestCallbackCallingWait(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"inline":       {WithRegistrationGrace(0)},
+		"hook timeout": {WithRegistrationGrace(0), WithHookTimeout(time.Minute)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			term := NewTerminator([]os.Signal{os.Interrupt}, opts...)
+			term.Add("db", func(ctx context.Context) error { return nil })
+
+			waited := make(chan error, 2)
+			term.SetCallback(func(result TerminationResult) {
+				waited <- term.WaitErr(time.Minute)
+
+				// A goroutine started by the callback, such as a
+				// helper it waits for, does not deadlock either.
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					waited <- term.WaitErr(time.Minute)
+				}()
+				<-done
+			})
+
+			term.(*terminator).signalChan <- os.Interrupt
+			if !term.Wait(time.Second) {
+				t.Fatal("Expected Wait from the callback not to deadlock the termination")
+			}
+			for i := 0; i < 2; i++ {
+				if err := <-waited; err != nil {
+					t.Errorf("Expected Wait from the callback to report the completion, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestPartialCallbackCallingWait(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithCallbackOnWaitTimeout())
+
+	release := make(chan struct{})
+	term.Add("db", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	waited := make(chan error, 1)
+	term.SetCallback(func(result TerminationResult) {
+		waited <- term.WaitErr(20 * time.Millisecond)
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if term.Wait(20 * time.Millisecond) {
+		t.Fatal("Wait should have timed out")
+	}
+	if err := <-waited; err != ErrWaitTimeout {
+		t.Errorf("Expected Wait from the partial callback to time out, got %v", err)
+	}
+
+	close(release)
+	if !term.Wait(time.Second) {
+		t.Error("Termination timed out")
+	}
+}
@@ -0,0 +1,87 @@
+package terminator
+
+import "unsafe"
+
+// WithLoopCaptureCheck is a debug option warning about the classic bug of
+// closers registered in a loop and capturing the loop variable, which then
+// all close the last resource of the loop in modules declaring go 1.21 or
+// earlier:
+//
+//	for _, conn := range conns {
+//		term.Add(conn.Name, func(ctx context.Context) error { return conn.Close() })
+//	}
+//
+// A warning is logged once n closers built by the same function literal
+// capture the same first variable. The check inspects the closures with
+// unsafe and is a heuristic: closers sharing a variable legitimately, such as
+// a logger captured first, are reported as well.
+func WithLoopCaptureCheck(n int) Option {
+	return func(c *config) {
+		c.loopCaptureCheck = n
+	}
+}
+
+// captureGroup gathers the closers built by the same function literal.
+type captureGroup struct {
+	// first is the first closer of the group, kept to inspect its captured
+	// variables once the group is known to hold distinct closures.
+	first CloseFunc
+
+	// captured counts the closures of the group by their first captured variable,
+	// nil while all of them are the same function value.
+	captured map[uintptr]int
+	warned   bool
+}
+
+// funcval returns the closure of the function value, made of the code
+// pointer followed by the captured variables.
+func funcval(fn CloseFunc) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
+
+// firstCaptured returns the first word captured by the closure, which must
+// capture at least one variable.
+func firstCaptured(closure unsafe.Pointer) uintptr {
+	return *(*uintptr)(unsafe.Pointer(uintptr(closure) + unsafe.Sizeof(uintptr(0))))
+}
+
+// checkLoopCapture records the closer and reports whether it completes a
+// group of closures sharing their first captured variable, along with the
+// size of the group. It must be called with t.mu held.
+func (t *terminator) checkLoopCapture(closer payload) (int, bool) {
+	if t.config.loopCaptureCheck <= 0 || closer.Close == nil {
+		return 0, false
+	}
+
+	closure := funcval(closer.Close)
+	code := *(*uintptr)(closure)
+
+	if t.captureGroups == nil {
+		t.captureGroups = make(map[uintptr]*captureGroup)
+	}
+	group, ok := t.captureGroups[code]
+	if !ok {
+		t.captureGroups[code] = &captureGroup{first: closer.Close}
+		return 0, false
+	}
+	if group.warned {
+		return 0, false
+	}
+
+	// A function literal capturing nothing is a single static function
+	// value: only distinct closures have captured variables to compare.
+	if group.captured == nil {
+		first := funcval(group.first)
+		if first == closure {
+			return 0, false
+		}
+		group.captured = map[uintptr]int{firstCaptured(first): 1}
+		group.first = nil
+	}
+
+	captured := firstCaptured(closure)
+	group.captured[captured]++
+	group.warned = group.captured[captured] >= t.config.loopCaptureCheck
+
+	return group.captured[captured], group.warned
+}
@@ -0,0 +1,46 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLoopCaptureCheck(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithLoopCaptureCheck(5))
+
+	// The module declares go 1.20, so every closure captures the same i.
+	result := []string{}
+	for i := 0; i < 10; i++ {
+		term.Add("app"+strconv.Itoa(i), func(ctx context.Context) error {
+			result = append(result, "app"+strconv.Itoa(i))
+			return nil
+		})
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "app4") {
+		t.Errorf("Expected a single warning once 5 closers share i, got %q", logger.lines)
+	}
+}
+
+func TestLoopCaptureCheckDistinctClosures(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithLoopCaptureCheck(2))
+
+	noop := func(ctx context.Context) error { return nil }
+	for i := 0; i < 10; i++ {
+		i := i
+		term.Add("app"+strconv.Itoa(i), func(ctx context.Context) error {
+			_ = i
+			return nil
+		})
+		term.Add("noop"+strconv.Itoa(i), noop)
+	}
+
+	if len(logger.lines) != 0 {
+		t.Errorf("Expected no warning, got %q", logger.lines)
+	}
+}
@@ -0,0 +1,68 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReasonParent is the reason of the terminations of child terminators, see Terminator.Child.
+const ReasonParent = "parent"
+
+// Child returns a terminator closed as a single resource of t, registered
+// with the given name and options. The child does not listen to signals:
+// when its closer runs, the child closes its own resources within the
+// deadline and cancellation of the closer's context, so the remaining budget
+// of the parent carries over. The results of the child's resources are
+// reported as the SubResults of its result data in the parent.
+// It returns the error of the registration, if any, as the child would
+// never be closed.
+func (t *terminator) Child(name string, opts ...CloserOption) (Terminator, error) {
+	child := newTerminator([]Option{WithRegistrationGrace(0), WithLogger(t.config.logger)})
+
+	closer := payload{Name: name, Close: child.closeAsChild}
+	for _, opt := range opts {
+		opt(&closer)
+	}
+
+	_, err := t.push(closer)
+	t.ensureMonitor()
+	if err != nil {
+		return nil, err
+	}
+
+	return child, nil
+}
+
+// closeAsChild triggers the termination of the child terminator t with the
+// context of its closer in the parent, and waits for it to complete.
+func (t *terminator) closeAsChild(ctx context.Context) error {
+	t.ensureMonitor()
+
+	if t.State() == StateIdle {
+		select {
+		case t.triggerChan <- trigger{reason: ReasonParent, ctx: ctx}:
+		default:
+		}
+	}
+
+	var err error
+	select {
+	case <-t.completedChan:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result, _ := t.Result()
+	for _, termData := range result.Result {
+		ReportSubResult(ctx, termData)
+	}
+
+	if err != nil {
+		return err
+	}
+	if result.FailedOrTimeoutCount > 0 {
+		return fmt.Errorf("terminator: %d of %d resources failed or timed out", result.FailedOrTimeoutCount, result.closed)
+	}
+
+	return nil
+}
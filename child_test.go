@@ -0,0 +1,87 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestChild(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithShutdownBudget(time.Minute))
+
+	tenant, err := term.Child("tenant", InPhase(PhaseStorage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deadline time.Time
+	tenant.Add("db", func(ctx context.Context) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	})
+	tenant.Add("cache", func(ctx context.Context) error {
+		return errors.New("flush failed")
+	})
+	term.Add("server", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if until := time.Until(deadline); until <= 50*time.Second || until > time.Minute {
+		t.Errorf("Expected the child to inherit the remaining budget, got a deadline in %v", until)
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 2 || result.Result[1].Name != "tenant" {
+		t.Fatalf("Expected the child to be closed after the server, got %+v", result.Result)
+	}
+
+	child := result.Result[1]
+	if child.Status != FAILED || child.Error == nil {
+		t.Errorf("Expected the child to fail with its cache, got %+v", child)
+	}
+	if len(child.SubResults) != 2 || child.SubResults[0].Name != "cache" || child.SubResults[1].Status != SUCCESS {
+		t.Errorf("Expected the results of the child as sub-results, got %+v", child.SubResults)
+	}
+
+	if state := tenant.State(); state != StateDone {
+		t.Errorf("Expected the child to be done, got %v", state)
+	}
+	if childResult, _ := tenant.Result(); childResult.Reason != ReasonParent {
+		t.Errorf("Expected reason %q, got %q", ReasonParent, childResult.Reason)
+	}
+}
+
+func TestChildTimeout(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	tenant, err := term.Child("tenant", WithCloserTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant.Add("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The child should be bounded by its closer timeout")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Kind != ErrorKindTimeout {
+		t.Errorf("Expected the child to time out, got %+v", result.Result[0])
+	}
+
+	if !tenant.Wait(1 * time.Second) {
+		t.Fatal("The child should complete once its context expired")
+	}
+	if childResult, _ := tenant.Result(); childResult.Result[0].Kind != ErrorKindTimeout {
+		t.Errorf("Expected the child's resources to share the deadline, got %+v", childResult.Result[0])
+	}
+}
@@ -0,0 +1,248 @@
+// Package cloudevents exports termination results as CloudEvents, for fleets
+// that aggregate lifecycle events centrally. Events are encoded in the JSON
+// structured mode of the CloudEvents 1.0 specification and written to an
+// io.Writer or posted to an HTTP endpoint.
+//
+// The data of the events follows a versioned schema: fields are only added
+// within a SchemaVersion, and any other change comes with a new version and
+// a new event type.
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+const (
+
+	// SpecVersion is the version of the CloudEvents specification of the events.
+	SpecVersion = "1.0"
+
+	// SchemaVersion is the version of the schema of the event data.
+	SchemaVersion = 1
+
+	// EventType is the type of the events carrying a termination result.
+	EventType = "com.github.rohanpoojary.terminator.result.v1"
+
+	// DataSchema identifies the schema of the event data.
+	DataSchema = "https://github.com/RohanPoojary/go-terminator/cloudevents/schema/v1"
+
+	// ContentType is the media type of the events in JSON structured mode.
+	ContentType = "application/cloudevents+json"
+)
+
+// Event is a CloudEvent in JSON structured mode.
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	DataSchema      string    `json:"dataschema"`
+	Data            Data      `json:"data"`
+}
+
+// Data is the data of an event, the termination result in version SchemaVersion of the schema.
+type Data struct {
+	SchemaVersion        int     `json:"schemaVersion"`
+	Signal               string  `json:"signal,omitempty"`
+	Reason               string  `json:"reason"`
+	FailedOrTimeoutCount int     `json:"failedOrTimeoutCount"`
+	Partial              bool    `json:"partial"`
+	Aborted              bool    `json:"aborted,omitempty"`
+	DurationMs           int64   `json:"durationMs"`
+	WithinSLO            bool    `json:"withinSLO"`
+	Error                string  `json:"error,omitempty"`
+	Results              []Entry `json:"results"`
+}
+
+// Entry is the result of closing a single resource.
+type Entry struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Kind       string  `json:"kind,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	Steps      int     `json:"steps,omitempty"`
+	DurationMs int64   `json:"durationMs"`
+	Detached   bool    `json:"detached,omitempty"`
+	SubResults []Entry `json:"subResults,omitempty"`
+}
+
+// NewData converts a termination result to the event data.
+func NewData(result terminator.TerminationResult) Data {
+	data := Data{
+		SchemaVersion:        SchemaVersion,
+		Reason:               result.Reason,
+		FailedOrTimeoutCount: result.FailedOrTimeoutCount,
+		Partial:              result.Partial,
+		Aborted:              result.Aborted,
+		DurationMs:           result.SLO.Duration.Milliseconds(),
+		WithinSLO:            result.SLO.WithinSLO,
+		Results:              make([]Entry, 0, len(result.Result)),
+	}
+
+	if result.Signal != nil {
+		data.Signal = result.Signal.String()
+	}
+	if err := result.Err(); err != nil {
+		data.Error = err.Error()
+	}
+
+	for _, termData := range result.Result {
+		data.Results = append(data.Results, newEntry(termData))
+	}
+
+	return data
+}
+
+// newEntry converts the result data of a resource, and of its sub-resources, to an entry.
+func newEntry(termData terminator.TerminationResultData) Entry {
+	entry := Entry{
+		Name:       termData.Name,
+		Status:     termData.Status.String(),
+		Kind:       string(termData.Kind),
+		Steps:      termData.Steps,
+		DurationMs: termData.Duration.Milliseconds(),
+		Detached:   termData.Detached,
+	}
+	if termData.Error != nil {
+		entry.Error = termData.Error.Error()
+	}
+
+	for _, subData := range termData.SubResults {
+		entry.SubResults = append(entry.SubResults, newEntry(subData))
+	}
+
+	return entry
+}
+
+// Exporter emits termination results as CloudEvents.
+type Exporter struct {
+	source  string
+	subject string
+
+	// send delivers an encoded event.
+	send func(ctx context.Context, body []byte) error
+}
+
+// Option configures an Exporter.
+type Option func(*Exporter)
+
+// WithSubject sets the subject of the events, such as the instance name.
+func WithSubject(subject string) Option {
+	return func(e *Exporter) {
+		e.subject = subject
+	}
+}
+
+// NewWriterExporter returns an exporter writing every event to w as a single
+// line of JSON. The source identifies the application in the fleet.
+func NewWriterExporter(w io.Writer, source string, opts ...Option) *Exporter {
+	e := &Exporter{source: source}
+	e.send = func(ctx context.Context, body []byte) error {
+		_, err := w.Write(append(body, '\n'))
+		return err
+	}
+
+	return e.apply(opts)
+}
+
+// NewHTTPExporter returns an exporter posting every event to url with
+// client, or http.DefaultClient if nil. Responses other than 2xx are
+// reported as errors. The source identifies the application in the fleet.
+func NewHTTPExporter(url string, client *http.Client, source string, opts ...Option) *Exporter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	e := &Exporter{source: source}
+	e.send = func(ctx context.Context, body []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", ContentType)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("cloudevents: unexpected response status %s", resp.Status)
+		}
+
+		return nil
+	}
+
+	return e.apply(opts)
+}
+
+// apply applies the options to the exporter.
+func (e *Exporter) apply(opts []Option) *Exporter {
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// NewEvent returns the event carrying result.
+func (e *Exporter) NewEvent(result terminator.TerminationResult) (Event, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		SpecVersion:     SpecVersion,
+		ID:              hex.EncodeToString(id),
+		Source:          e.source,
+		Type:            EventType,
+		Subject:         e.subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      DataSchema,
+		Data:            NewData(result),
+	}, nil
+}
+
+// Export emits result as an event.
+func (e *Exporter) Export(ctx context.Context, result terminator.TerminationResult) error {
+	event, err := e.NewEvent(result)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return e.send(ctx, body)
+}
+
+// Callback returns a callback for Terminator.SetCallback exporting the final
+// result, bounded by timeout. Export errors are passed to onError, if not nil.
+func (e *Exporter) Callback(timeout time.Duration, onError func(error)) func(terminator.TerminationResult) {
+	return func(result terminator.TerminationResult) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := e.Export(ctx, result); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+func testResult() terminator.TerminationResult {
+	return terminator.TerminationResult{
+		Signal:               os.Interrupt,
+		Reason:               terminator.ReasonSignal,
+		FailedOrTimeoutCount: 1,
+		Result: []terminator.TerminationResultData{
+			{Name: "db", Status: terminator.SUCCESS, Duration: 20 * time.Millisecond},
+			{Name: "cache", Status: terminator.FAILED, Error: errors.New("flush failed"), Kind: terminator.ErrorKindUnknown},
+		},
+	}
+}
+
+func TestWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := NewWriterExporter(&buf, "/fleet/api", WithSubject("api-1"))
+
+	if err := exporter.Export(context.Background(), testResult()); err != nil {
+		t.Fatal(err)
+	}
+
+	var event Event
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("Unexpected output %q: %v", buf.String(), err)
+	}
+
+	if event.SpecVersion != SpecVersion || event.Type != EventType || event.Source != "/fleet/api" || event.Subject != "api-1" || event.ID == "" {
+		t.Errorf("Unexpected event attributes %+v", event)
+	}
+	if event.Data.SchemaVersion != SchemaVersion || event.Data.Signal != os.Interrupt.String() || event.Data.FailedOrTimeoutCount != 1 {
+		t.Errorf("Unexpected event data %+v", event.Data)
+	}
+	if len(event.Data.Results) != 2 || event.Data.Results[0].DurationMs != 20 || event.Data.Results[1].Error != "flush failed" {
+		t.Errorf("Unexpected results %+v", event.Data.Results)
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var contentType string
+	var body []byte
+	status := http.StatusAccepted
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	exporter := NewHTTPExporter(server.URL, nil, "/fleet/api")
+
+	if err := exporter.Export(context.Background(), testResult()); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != ContentType {
+		t.Errorf("Expected content type %q, got %q", ContentType, contentType)
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil || event.Data.Reason != terminator.ReasonSignal {
+		t.Errorf("Unexpected body %q: %v", body, err)
+	}
+
+	status = http.StatusInternalServerError
+	if err := exporter.Export(context.Background(), testResult()); err == nil {
+		t.Error("Expected an error for a failed delivery")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// childEnv marks the processes started by the children example.
+const childEnv = "TERMINATOR_EXAMPLES_CHILD"
+
+// runChildren starts -n child processes, each with a terminator of its own,
+// and stops them on shutdown: they are interrupted, and killed if they do
+// not exit within -grace. A shutdown hook command runs last.
+func runChildren(args []string) int {
+	flags, after := newFlags("children")
+	children := flags.Int("n", 2, "number of child processes")
+	grace := flags.Duration("grace", 2*time.Second, "time the children have to exit")
+	flags.Parse(args)
+
+	executable, err := os.Executable()
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	term := terminator.NewTerminator(signals, terminator.WithTriggerSource(afterTrigger(*after)))
+
+	// The hook runs the child mode of this very binary, which exits at once.
+	hook := exec.Command(executable)
+	hook.Env = append(os.Environ(), childEnv+"=hook")
+	hook.Stdout = os.Stdout
+	term.AddCommand("shutdown hook", hook, terminator.InPhase(terminator.PhaseFinalizer))
+
+	// The children are closed as a single resource of a child terminator.
+	group, err := term.Child("children")
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	for child := 0; child < *children; child++ {
+		cmd := exec.Command(executable)
+		cmd.Env = append(os.Environ(), childEnv+"="+strconv.Itoa(child))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			log.Print(err)
+			return 1
+		}
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- cmd.Wait()
+		}()
+
+		group.AddWithTimeout("child "+strconv.Itoa(child), func(ctx context.Context) error {
+			if err := cmd.Process.Signal(os.Interrupt); err != nil {
+				// Interrupting processes is not supported on Windows.
+				cmd.Process.Kill()
+			}
+
+			select {
+			case err := <-exited:
+				return err
+			case <-ctx.Done():
+				cmd.Process.Kill()
+				return ctx.Err()
+			}
+		}, *grace)
+	}
+
+	term.Ready()
+
+	return report(term)
+}
+
+// runChild is the main function of the processes started by the children
+// example: it waits for the termination, and takes a moment to close.
+func runChild() int {
+	name := "child " + os.Getenv(childEnv)
+	if os.Getenv(childEnv) == "hook" {
+		log.Print("shutdown hook: run")
+		return 0
+	}
+
+	term := terminator.NewTerminator(signals)
+	term.Add(name, func(ctx context.Context) error {
+		log.Printf("%s: closing", name)
+		return terminator.Sleep(ctx, 100*time.Millisecond)
+	})
+	log.Printf("%s: started", name)
+
+	term.Wait(0)
+	log.Printf("%s: closed", name)
+
+	return 0
+}
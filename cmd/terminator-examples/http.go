@@ -0,0 +1,69 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// httpSupported reports whether the http example is built in.
+const httpSupported = true
+
+// runHTTP serves requests taking -work to complete, and drains them on
+// shutdown: the readiness probe fails first, then the server stops
+// accepting connections and waits for the requests in flight.
+func runHTTP(args []string) int {
+	flags, after := newFlags("http")
+	addr := flags.String("addr", "127.0.0.1:8080", "address to listen on")
+	work := flags.Duration("work", 500*time.Millisecond, "time every request takes")
+	flags.Parse(args)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	mux := http.NewServeMux()
+	srv := &http.Server{Handler: mux}
+
+	term, err := terminator.NewWebService(srv,
+		terminator.WithPreStopDelay(100*time.Millisecond),
+		terminator.WithDrainTimeout(5*time.Second),
+		terminator.WithServiceOptions(terminator.WithTriggerSource(afterTrigger(*after))),
+	)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	mux.Handle("/ready", terminator.ReadinessHandler(term))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// The request context outlives the start of the termination by the
+		// request grace period, so the request in flight completes.
+		ctx := term.RequestContext(r.Context())
+		if err := terminator.Sleep(ctx, *work); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "done")
+	})
+
+	go func() {
+		if err := srv.Serve(listener); err != http.ErrServerClosed {
+			log.Printf("serve: %v", err)
+		}
+	}()
+
+	log.Printf("listening on http://%s", listener.Addr())
+	term.Ready()
+
+	return report(term)
+}
@@ -0,0 +1,19 @@
+//go:build terminator_nohttp
+// +build terminator_nohttp
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// httpSupported reports whether the http example is built in.
+const httpSupported = false
+
+// runHTTP reports that the http example is not available: NewWebService is
+// not built with the terminator_nohttp build tag.
+func runHTTP(args []string) int {
+	fmt.Fprintln(os.Stderr, "the http example is not available when built with the terminator_nohttp tag")
+	return 2
+}
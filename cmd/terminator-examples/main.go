@@ -0,0 +1,110 @@
+// Command terminator-examples runs examples of the terminator, one per
+// subcommand, each exercising a different part of the library:
+//
+//	terminator-examples http      an HTTP server drained on shutdown
+//	terminator-examples workers   a pool of workers stopping between jobs
+//	terminator-examples children  child processes stopped gracefully
+//	terminator-examples phases    resources closed phase by phase
+//
+// The examples run until interrupted, or until the duration given with
+// -after elapses, and exit with the exit code of the termination, so that
+// they double as integration tests of the library.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// signals are the signals triggering the termination of the examples.
+var signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// example runs an example with its command line arguments, returning its exit code.
+type example struct {
+	usage string
+	run   func(args []string) int
+}
+
+var examples = map[string]example{
+	"http":     {"an HTTP server drained on shutdown", runHTTP},
+	"workers":  {"a pool of workers stopping between jobs", runWorkers},
+	"children": {"child processes stopped gracefully", runChildren},
+	"phases":   {"resources closed phase by phase", runPhases},
+}
+
+func main() {
+	if os.Getenv(childEnv) != "" {
+		os.Exit(runChild())
+	}
+
+	os.Exit(run(os.Args[1:]))
+}
+
+// run runs the example named by the first argument.
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	ex, ok := examples[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown example %q\n", args[0])
+		usage()
+		return 2
+	}
+
+	return ex.run(args[1:])
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: terminator-examples <example> [-after duration] [flags]")
+	for _, name := range []string{"http", "workers", "children", "phases"} {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", name, examples[name].usage)
+	}
+}
+
+// newFlags returns the flags of an example, with the common -after flag.
+func newFlags(name string) (*flag.FlagSet, *time.Duration) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	after := flags.Duration("after", 0, "trigger the termination after this duration, instead of waiting for a signal")
+
+	return flags, after
+}
+
+// afterTrigger is a TriggerSource firing once a duration elapsed, or never
+// if it is zero.
+type afterTrigger time.Duration
+
+// Wait implements terminator.TriggerSource.
+func (d afterTrigger) Wait(ctx context.Context) error {
+	if d <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return terminator.Sleep(ctx, time.Duration(d))
+}
+
+// report logs the result of the termination and returns its exit code.
+func report(term terminator.Terminator) int {
+	term.Wait(0)
+
+	result, _ := term.Result()
+	for _, data := range result.Result {
+		log.Printf("%-24s %-8v %v", data.Name, data.Status, data.Duration.Round(time.Millisecond))
+		if data.Error != nil {
+			log.Printf("%-24s error: %v", "", data.Error)
+		}
+	}
+	log.Printf("terminated (%s) in %v", result.Reason, result.SLO.Duration.Round(time.Millisecond))
+
+	return result.ExitCode()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// The children example starts this test binary as its children.
+	if os.Getenv(childEnv) != "" {
+		os.Exit(runChild())
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestExamples(t *testing.T) {
+	for name, args := range map[string][]string{
+		"http":     {"-addr", "127.0.0.1:0", "-work", "10ms"},
+		"workers":  {"-interval", "10ms"},
+		"children": {},
+		"phases":   {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if name == "http" && !httpSupported {
+				t.Skip("built without net/http")
+			}
+
+			args := append([]string{name, "-after", "100ms"}, args...)
+			if code := run(args); code != 0 {
+				t.Errorf("Expected the %s example to exit with 0, got %d", name, code)
+			}
+		})
+	}
+}
+
+func TestUnknownExample(t *testing.T) {
+	if code := run([]string{"unknown"}); code != 2 {
+		t.Errorf("Expected an unknown example to exit with 2, got %d", code)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// runPhases registers resources across the phases of the termination and
+// logs its timeline, projected first with DryRun, then as it happens.
+func runPhases(args []string) int {
+	flags, after := newFlags("phases")
+	flags.Parse(args)
+
+	term := terminator.NewTerminator(signals,
+		terminator.WithTriggerSource(afterTrigger(*after)),
+		terminator.WithShutdownBudget(5*time.Second),
+		terminator.WithEventHandler(func(event terminator.Event) {
+			switch event.Kind {
+			case terminator.EventCloserStarted:
+				log.Printf("closing %s", event.Name)
+			case terminator.EventStateChanged:
+				log.Printf("state %v -> %v", event.From, event.To)
+			}
+		}),
+	)
+
+	durations := map[string]time.Duration{}
+	add := func(name string, phase terminator.Phase, d time.Duration) {
+		durations[name] = d
+		term.AddWithOptions(name, func(ctx context.Context) error {
+			return terminator.Sleep(ctx, d)
+		}, terminator.InPhase(phase), terminator.WithCloserTimeout(time.Second))
+	}
+
+	add("server", terminator.PhaseServer, 100*time.Millisecond)
+	add("workers", terminator.PhaseWorkers, 150*time.Millisecond)
+	add("commit offsets", terminator.PhaseCommit, 50*time.Millisecond)
+	add("database", terminator.PhaseStorage, 50*time.Millisecond)
+	add("telemetry", terminator.PhaseTelemetry, 20*time.Millisecond)
+
+	plan := term.DryRun(terminator.Simulation{Durations: durations})
+	for _, entry := range plan.Entries {
+		log.Printf("projected: %-16s %v from %v to %v", entry.Name, entry.Phase, entry.Start, entry.End)
+	}
+	log.Printf("projected: %v in total, within %v", plan.Total, plan.Deadline)
+
+	term.Ready()
+
+	return report(term)
+}
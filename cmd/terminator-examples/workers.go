@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// runWorkers runs a pool of workers handling jobs produced every -interval.
+// On shutdown the producer stops first, then the workers finish the job at
+// hand and exit at a job boundary, instead of being interrupted midway.
+func runWorkers(args []string) int {
+	flags, after := newFlags("workers")
+	workers := flags.Int("n", 4, "number of workers")
+	interval := flags.Duration("interval", 50*time.Millisecond, "time between two jobs")
+	flags.Parse(args)
+
+	term := terminator.NewTerminator(signals, terminator.WithTriggerSource(afterTrigger(*after)))
+	jobs := make(chan int)
+
+	// The producer stops in PhaseIntake, before the workers. Within their
+	// phase, the boundary registered last is closed first, so the pool only
+	// waits for workers already told to stop.
+	pool, err := term.Group("pool", terminator.InPhase(terminator.PhaseWorkers))
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	boundary, err := term.JobBoundary("workers", terminator.InPhase(terminator.PhaseWorkers))
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	for worker := 0; worker < *workers; worker++ {
+		worker := worker
+		if !boundary.Join() {
+			break
+		}
+		pool.Go(func(ctx context.Context) error {
+			defer boundary.Stopped()
+
+			for {
+				select {
+				case job := <-jobs:
+					log.Printf("worker %d: job %d", worker, job)
+					time.Sleep(*interval)
+				case <-boundary.StopCh():
+					log.Printf("worker %d: stopped", worker)
+					return nil
+				}
+			}
+		})
+	}
+
+	err = term.Go("producer", func(ctx context.Context) error {
+		for job := 0; ; job++ {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if err := terminator.Sleep(ctx, *interval); err != nil {
+				return err
+			}
+		}
+	}, terminator.InPhase(terminator.PhaseIntake))
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	term.Ready()
+
+	return report(term)
+}
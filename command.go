@@ -0,0 +1,109 @@
+package terminator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+)
+
+// maxCommandStderr bounds the standard error of a command kept for its error.
+const maxCommandStderr = 4 << 10
+
+// AddCommand registers an external hook, such as a script provided by the
+// operators, run when the resource is closed. The command runs within the
+// timeout of the closer, see WithCloserTimeout, and its process is killed once the
+// deadline is exceeded; processes it started itself are not. A non-zero exit
+// status fails the closer, with the end of the standard error of the command
+// in the error if cmd.Stderr is nil. cmd is started the first time the
+// resource is closed, and a copy of it when the hook is run again by
+// RetryFailed, killed at the deadline instead of canceled with cmd.Cancel.
+func (t *terminator) AddCommand(name string, cmd *exec.Cmd, opts ...CloserOption) (*Handle, error) {
+	return t.AddWithOptions(name, commandCloser(cmd), opts...)
+}
+
+// AddScript registers the script at path, run with args when the resource
+// is closed, like AddCommand.
+func (t *terminator) AddScript(name, path string, args ...string) (*Handle, error) {
+	return t.AddCommand(name, exec.Command(path, args...))
+}
+
+// commandCloser returns a CloseFunc running cmd, canceled once ctx is done.
+// The error of the lookup of the program, such as exec.ErrDot, is returned
+// without starting anything. cmd itself is run the first time, so that its
+// Cancel function and context apply, and a copy of it on the next runs, as
+// a command cannot be started twice.
+func commandCloser(cmd *exec.Cmd) CloseFunc {
+	captureStderr := cmd.Stderr == nil
+	var started uint32
+
+	return func(ctx context.Context) error {
+		if cmd.Err != nil {
+			return cmd.Err
+		}
+
+		run := cmd
+		if !atomic.CompareAndSwapUint32(&started, 0, 1) {
+			run = &exec.Cmd{
+				Path:        cmd.Path,
+				Args:        cmd.Args,
+				Env:         cmd.Env,
+				Dir:         cmd.Dir,
+				Stdin:       cmd.Stdin,
+				Stdout:      cmd.Stdout,
+				Stderr:      cmd.Stderr,
+				ExtraFiles:  cmd.ExtraFiles,
+				SysProcAttr: cmd.SysProcAttr,
+				WaitDelay:   cmd.WaitDelay,
+			}
+		}
+
+		var stderr tailBuffer
+		if captureStderr {
+			run.Stderr = &stderr
+		}
+
+		if err := run.Start(); err != nil {
+			return err
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- run.Wait()
+		}()
+
+		select {
+		case err := <-done:
+			if output := strings.TrimSpace(stderr.String()); err != nil && output != "" {
+				return fmt.Errorf("%w: %s", err, output)
+			}
+			return err
+		case <-ctx.Done():
+			// Not waiting for the process: the processes it started could
+			// hold its standard error open past the deadline.
+			if run.Cancel != nil {
+				run.Cancel()
+			} else {
+				run.Process.Kill()
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// tailBuffer keeps the last maxCommandStderr bytes written to it.
+type tailBuffer struct {
+	bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	n, _ := b.Buffer.Write(p)
+	if overflow := b.Len() - maxCommandStderr; overflow > 0 {
+		b.Next(overflow)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,143 @@
+//go:build !windows
+// +build !windows
+
+package terminator
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	marker := filepath.Join(dir, "ran")
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	term.AddCommand("hook", exec.Command("sh", "-c", "touch "+marker))
+	term.AddCommand("failing", exec.Command("sh", "-c", "echo cannot reach the registry >&2; exit 3"))
+	term.AddCommand("stuck", exec.Command("sleep", "10"), WithCloserTimeout(50*time.Millisecond))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("The stuck command should be killed at its deadline")
+	}
+
+	result, _ := term.Result()
+	for _, termData := range result.Result {
+		switch termData.Name {
+		case "hook":
+			if termData.Status != SUCCESS {
+				t.Errorf("Expected the hook to succeed, got %v", termData.Error)
+			}
+		case "failing":
+			var exitErr *exec.ExitError
+			if !errors.As(termData.Error, &exitErr) || exitErr.ExitCode() != 3 || !strings.Contains(termData.Error.Error(), "cannot reach the registry") {
+				t.Errorf("Expected exit status 3 along with the standard error, got %v", termData.Error)
+			}
+		case "stuck":
+			if termData.Kind != ErrorKindTimeout {
+				t.Errorf("Expected the stuck command to time out, got %v", termData.Error)
+			}
+		}
+	}
+
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("Expected the hook to run: %v", err)
+	}
+}
+
+func TestAddScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "hook.sh")
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\ntest \"$1\" = drain\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	term.AddScript("drain", script, "drain")
+	term.AddScript("wrong", script, "other")
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.FailedOrTimeoutCount != 1 {
+		t.Errorf("Expected only the script run with the wrong argument to fail, got %+v", result.Result)
+	}
+
+	retried := result.RetryFailed(context.Background())
+	if len(retried.Result) != 2 || !retried.Result[0].Retried {
+		t.Errorf("Expected the script to run again, got %+v", retried.Result)
+	}
+}
+
+func TestAddCommandLookupError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	marker := filepath.Join(dir, "ran")
+
+	// As set by exec.Command for a program found in the current directory.
+	dot := exec.Command("sh", "-c", "touch "+marker)
+	dot.Err = exec.ErrDot
+
+	canceled := make(chan struct{}, 1)
+	stuck := exec.CommandContext(context.Background(), "sleep", "10")
+	stuck.Cancel = func() error {
+		canceled <- struct{}{}
+		return stuck.Process.Kill()
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	term.AddCommand("missing", exec.Command("terminator-no-such-program"))
+	term.AddCommand("dot", dot)
+	term.AddCommand("stuck", stuck, WithCloserTimeout(50*time.Millisecond))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	for _, termData := range result.Result {
+		switch termData.Name {
+		case "missing":
+			if !errors.Is(termData.Error, exec.ErrNotFound) {
+				t.Errorf("Expected exec.ErrNotFound, got %v", termData.Error)
+			}
+		case "dot":
+			if !errors.Is(termData.Error, exec.ErrDot) {
+				t.Errorf("Expected exec.ErrDot, got %v", termData.Error)
+			}
+		}
+	}
+
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected the command refused by the lookup not to run")
+	}
+	select {
+	case <-canceled:
+	default:
+		t.Error("Expected the Cancel function of the command to stop it")
+	}
+}
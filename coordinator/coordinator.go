@@ -0,0 +1,280 @@
+// Package coordinator orchestrates the termination of several local
+// processes, such as an application and its sidecars. The parent process
+// listens on a unix socket with Listen, the other processes connect with
+// Join, and the parent closes them in order as part of its own termination,
+// collecting their termination results.
+package coordinator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// defaultMemberTimeout is the time a member has to terminate when the closer context has no deadline.
+const defaultMemberTimeout = 30 * time.Second
+
+// Signal is reported in the termination result of members terminated by the coordinator.
+var Signal = coordinatorSignal{}
+
+type coordinatorSignal struct{}
+
+func (coordinatorSignal) String() string { return "coordinator shutdown" }
+func (coordinatorSignal) Signal()        {}
+
+// member is a process connected to the coordinator.
+type member struct {
+	name  string
+	order int
+	conn  net.Conn
+	dec   *json.Decoder
+	enc   *json.Encoder
+
+	// replies receives the messages read from the member, until gone is
+	// closed as the connection is, with the read error in err.
+	replies chan message
+	gone    chan struct{}
+	err     error
+}
+
+// Coordinator accepts member processes on a unix socket and terminates them in order.
+type Coordinator struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	members []*member
+	results map[string]terminator.TerminationResult
+}
+
+// Listen creates a coordinator listening on the unix socket at path.
+func Listen(path string) (*Coordinator, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Coordinator{
+		listener: listener,
+		results:  make(map[string]terminator.TerminationResult),
+	}
+
+	go c.accept()
+
+	return c, nil
+}
+
+// accept registers the connecting members until the listener is closed.
+func (c *Coordinator) accept() {
+	for {
+		conn, err := c.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go c.register(conn)
+	}
+}
+
+// register reads the hello message of a new member.
+func (c *Coordinator) register(conn net.Conn) {
+	m := &member{
+		conn: conn,
+		dec:  json.NewDecoder(bufio.NewReader(conn)),
+		enc:  json.NewEncoder(conn),
+
+		replies: make(chan message, 1),
+		gone:    make(chan struct{}),
+	}
+
+	var hello message
+	if err := m.dec.Decode(&hello); err != nil || hello.Type != typeHello {
+		conn.Close()
+		return
+	}
+
+	m.name = hello.Name
+	m.order = hello.Order
+
+	c.mu.Lock()
+	c.members = append(c.members, m)
+	c.mu.Unlock()
+
+	c.read(m)
+}
+
+// read forwards the messages of m until its connection is closed, then
+// removes m from the members, so that a member gone, such as a process that
+// exited, doesn't stall the closer until its timeout.
+func (c *Coordinator) read(m *member) {
+	defer close(m.gone)
+	defer c.remove(m)
+
+	for {
+		var msg message
+		if err := m.dec.Decode(&msg); err != nil {
+			m.err = err
+			m.conn.Close()
+			return
+		}
+
+		select {
+		case m.replies <- msg:
+		default:
+		}
+	}
+}
+
+// remove removes m from the members.
+func (c *Coordinator) remove(m *member) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, other := range c.members {
+		if other == m {
+			c.members = append(c.members[:i], c.members[i+1:]...)
+			return
+		}
+	}
+}
+
+// Members returns the names of the connected members in termination order.
+func (c *Coordinator) Members() []string {
+	members := c.ordered()
+
+	names := make([]string, 0, len(members))
+	for _, m := range members {
+		names = append(names, m.name)
+	}
+
+	return names
+}
+
+// ordered returns the members sorted by order, then by connection time.
+func (c *Coordinator) ordered() []*member {
+	c.mu.Lock()
+	members := make([]*member, len(c.members))
+	copy(members, c.members)
+	c.mu.Unlock()
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].order < members[j].order
+	})
+
+	return members
+}
+
+// Closer returns a CloseFunc terminating the members one at a time in
+// ascending order, waiting for each to report its termination result.
+// It fails if any member cannot be reached or fails to close a resource.
+func (c *Coordinator) Closer() terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		var failed []string
+
+		for _, m := range c.ordered() {
+			select {
+			case <-m.gone:
+				// The member left after the termination started.
+				continue
+			default:
+			}
+
+			result, err := c.terminate(ctx, m)
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", m.name, err))
+				continue
+			}
+
+			c.mu.Lock()
+			c.results[m.name] = result
+			c.mu.Unlock()
+
+			if result.FailedOrTimeoutCount > 0 {
+				failed = append(failed, fmt.Sprintf("%s: %d resources failed to close", m.name, result.FailedOrTimeoutCount))
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("coordinator: %v", failed)
+		}
+
+		return nil
+	}
+}
+
+// terminate asks a member to terminate and waits for its result.
+func (c *Coordinator) terminate(ctx context.Context, m *member) (terminator.TerminationResult, error) {
+	timeout := defaultMemberTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	m.conn.SetDeadline(time.Now().Add(timeout))
+
+	// Unblock the exchange if the closer context is canceled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			m.conn.SetDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	if err := m.enc.Encode(message{Type: typeShutdown, Timeout: timeout}); err != nil {
+		return terminator.TerminationResult{}, err
+	}
+
+	var reply message
+	select {
+	case reply = <-m.replies:
+	case <-m.gone:
+		// The result may be read right before the connection is closed.
+		select {
+		case reply = <-m.replies:
+		default:
+			return terminator.TerminationResult{}, m.err
+		}
+	}
+
+	if reply.Type != typeResult || reply.Result == nil {
+		return terminator.TerminationResult{}, errors.New("unexpected reply " + reply.Type)
+	}
+
+	return fromWire(reply.Result), nil
+}
+
+// Results returns the termination results reported by the members, by name.
+func (c *Coordinator) Results() map[string]terminator.TerminationResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	results := make(map[string]terminator.TerminationResult, len(c.results))
+	for name, result := range c.results {
+		results[name] = result
+	}
+
+	return results
+}
+
+// Close stops accepting members and disconnects the connected ones.
+func (c *Coordinator) Close() error {
+	err := c.listener.Close()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, m := range c.members {
+		m.conn.Close()
+	}
+
+	return err
+}
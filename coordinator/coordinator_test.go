@@ -0,0 +1,141 @@
+package coordinator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+	"github.com/RohanPoojary/go-terminator/terminatortest"
+)
+
+func TestCoordinator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.sock")
+
+	c, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var mu sync.Mutex
+	order := []string{}
+
+	newMember := func(name string, closeErr error) terminator.Terminator {
+		term := terminator.NewTerminator(nil, terminator.WithRegistrationGrace(0))
+		term.Add(name+"-resource", func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return closeErr
+		})
+		return term
+	}
+
+	if err := Join(path, "sidecar", 2, newMember("sidecar", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := Join(path, "proxy", 1, newMember("proxy", errors.New("flush failed"))); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; len(c.Members()) < 2; i++ {
+		if i == 100 {
+			t.Fatal("Members didn't register")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	parent := terminator.NewTerminator([]os.Signal{os.Interrupt}, terminator.WithRegistrationGrace(0))
+	parent.AddWithTimeout("members", c.Closer(), 5*time.Second)
+
+	var result terminator.TerminationResult
+	parent.SetCallback(func(r terminator.TerminationResult) {
+		result = r
+	})
+
+	terminatortest.InjectSignal(parent, os.Interrupt)
+	if !parent.Wait(5 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	mu.Lock()
+	if len(order) != 2 || order[0] != "proxy" || order[1] != "sidecar" {
+		t.Errorf("Members should terminate in order, got %v", order)
+	}
+	mu.Unlock()
+
+	if result.FailedOrTimeoutCount != 1 {
+		t.Errorf("The failing member should fail the closer, got %+v", result)
+	}
+
+	results := c.Results()
+	proxy, ok := results["proxy"]
+	if !ok || proxy.FailedOrTimeoutCount != 1 || proxy.Result[0].Error.Error() != "flush failed" {
+		t.Errorf("Unexpected proxy result: %+v", proxy)
+	}
+
+	sidecar, ok := results["sidecar"]
+	if !ok || sidecar.Result[0].Status != terminator.SUCCESS {
+		t.Errorf("Unexpected sidecar result: %+v", sidecar)
+	}
+}
+
+func TestCoordinatorPrunesGoneMembers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.sock")
+
+	c, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewEncoder(conn).Encode(message{Type: typeHello, Name: "exited", Order: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	member := terminator.NewTerminator(nil, terminator.WithRegistrationGrace(0))
+	if err := Join(path, "sidecar", 2, member); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; len(c.Members()) < 2; i++ {
+		if i == 100 {
+			t.Fatal("Members didn't register")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	conn.Close()
+	for i := 0; len(c.Members()) != 1; i++ {
+		if i == 100 {
+			t.Fatalf("The member gone should be removed, got %v", c.Members())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	if err := c.Closer()(ctx); err != nil {
+		t.Errorf("The member gone shouldn't fail the closer, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("The member gone shouldn't stall the closer, took %v", elapsed)
+	}
+
+	if result, ok := member.Result(); !ok || result.Reason != Reason || result.Signal != nil {
+		t.Errorf("Expected the member to terminate with Reason, got %+v", result)
+	}
+}
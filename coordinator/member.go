@@ -0,0 +1,51 @@
+package coordinator
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// Reason is the reason of the termination of the members terminated by the
+// coordinator.
+const Reason = "coordinator"
+
+// Join connects term to the coordinator listening on the unix socket at path.
+// When the coordinator reaches this member, in ascending order, term starts
+// its termination process with Reason and its result is reported back. The connection is closed once the result is sent.
+func Join(path, name string, order int, term terminator.Terminator) error {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(conn)
+	if err := enc.Encode(message{Type: typeHello, Name: name, Order: order}); err != nil {
+		conn.Close()
+		return err
+	}
+
+	go func() {
+		defer conn.Close()
+
+		var msg message
+		if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&msg); err != nil || msg.Type != typeShutdown {
+			return
+		}
+
+		// Terminate reports false if the member is terminating on its own.
+		term.Terminate(Reason)
+		term.Wait(msg.Timeout)
+
+		result, ok := term.Result()
+		if !ok {
+			return
+		}
+
+		enc.Encode(message{Type: typeResult, Result: toWire(result)})
+	}()
+
+	return nil
+}
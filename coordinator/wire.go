@@ -0,0 +1,91 @@
+package coordinator
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// Message types exchanged over the socket, one JSON object per line.
+const (
+	typeHello    = "hello"
+	typeShutdown = "shutdown"
+	typeResult   = "result"
+)
+
+// message is the envelope of everything sent over the socket.
+type message struct {
+	Type string `json:"type"`
+
+	// Set on hello
+	Name  string `json:"name,omitempty"`
+	Order int    `json:"order,omitempty"`
+
+	// Set on shutdown, the time the member has to complete its termination
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// Set on result
+	Result *wireResult `json:"result,omitempty"`
+}
+
+// wireResult is the serializable form of a terminator.TerminationResult.
+type wireResult struct {
+	Signal               string     `json:"signal"`
+	FailedOrTimeoutCount int        `json:"failedOrTimeoutCount"`
+	Partial              bool       `json:"partial,omitempty"`
+	Result               []wireData `json:"result"`
+}
+
+// wireData is the serializable form of a terminator.TerminationResultData.
+type wireData struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// toWire converts a termination result to its serializable form.
+func toWire(result terminator.TerminationResult) *wireResult {
+	wire := &wireResult{
+		FailedOrTimeoutCount: result.FailedOrTimeoutCount,
+		Partial:              result.Partial,
+		Result:               make([]wireData, 0, len(result.Result)),
+	}
+
+	if result.Signal != nil {
+		wire.Signal = result.Signal.String()
+	}
+
+	for _, data := range result.Result {
+		wd := wireData{Name: data.Name, Status: data.Status.String()}
+		if data.Error != nil {
+			wd.Error = data.Error.Error()
+		}
+		wire.Result = append(wire.Result, wd)
+	}
+
+	return wire
+}
+
+// fromWire converts a serialized termination result back. The signal is
+// reported as Signal, as the original value cannot be restored.
+func fromWire(wire *wireResult) terminator.TerminationResult {
+	result := terminator.TerminationResult{
+		Signal:               Signal,
+		FailedOrTimeoutCount: wire.FailedOrTimeoutCount,
+		Partial:              wire.Partial,
+		Result:               make([]terminator.TerminationResultData, 0, len(wire.Result)),
+	}
+
+	for _, wd := range wire.Result {
+		data := terminator.TerminationResultData{Name: wd.Name}
+		// A status unknown to this version, sent by a newer member, is left unset.
+		_ = data.Status.UnmarshalText([]byte(wd.Status))
+		if wd.Error != "" {
+			data.Error = errors.New(wd.Error)
+		}
+		result.Result = append(result.Result, data)
+	}
+
+	return result
+}
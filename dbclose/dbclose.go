@@ -0,0 +1,99 @@
+// Package dbclose closes database/sql pools without killing transactions
+// midway: the closer blocks new transactions, waits for the open ones to
+// commit or roll back, then closes the pool.
+package dbclose
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// ErrClosing is returned by BeginTx and Begin once the guard is closing.
+var ErrClosing = errors.New("dbclose: database is closing")
+
+// Guard tracks the open transactions of a pool.
+type Guard struct {
+	db      *sql.DB
+	tracker *terminator.Tracker
+}
+
+// New creates a guard of the transactions of db.
+func New(db *sql.DB) *Guard {
+	return &Guard{db: db, tracker: terminator.NewTracker()}
+}
+
+// Tx is a transaction tracked by a guard until it is committed or rolled back.
+type Tx struct {
+	*sql.Tx
+
+	doneOnce sync.Once
+	done     func()
+}
+
+// BeginTx starts a tracked transaction, see sql.DB.BeginTx. It returns
+// ErrClosing once the guard is closing.
+func (g *Guard) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	done, err := g.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := g.db.BeginTx(ctx, opts)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	return &Tx{Tx: tx, done: done}, nil
+}
+
+// Commit commits the transaction, see sql.Tx.Commit.
+func (tx *Tx) Commit() error {
+	defer tx.end()
+	return tx.Tx.Commit()
+}
+
+// Rollback rolls the transaction back, see sql.Tx.Rollback.
+func (tx *Tx) Rollback() error {
+	defer tx.end()
+	return tx.Tx.Rollback()
+}
+
+// end stops tracking the transaction.
+func (tx *Tx) end() {
+	tx.doneOnce.Do(tx.done)
+}
+
+// Begin registers a transaction run without BeginTx, such as through a
+// driver-specific API, which must call done once it is committed or rolled
+// back. It returns ErrClosing once the guard is closing.
+func (g *Guard) Begin() (done func(), err error) {
+	if !g.tracker.Begin() {
+		return nil, ErrClosing
+	}
+
+	var once sync.Once
+	return func() { once.Do(g.tracker.Done) }, nil
+}
+
+// Open returns the number of open transactions.
+func (g *Guard) Open() int {
+	return g.tracker.Active()
+}
+
+// Close is the closer of the pool: it blocks new transactions, waits for
+// the open ones to complete or ctx to be done, then closes the pool. When ctx
+// is done first, its error is returned and the pool is closed in the
+// background, as closing it waits for the queries in progress.
+func (g *Guard) Close(ctx context.Context) error {
+	if err := g.tracker.Drain(ctx); err != nil {
+		go g.db.Close()
+		return err
+	}
+
+	return g.db.Close()
+}
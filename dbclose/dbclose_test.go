@@ -0,0 +1,106 @@
+package dbclose
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a database/sql driver supporting transactions only.
+type fakeDriver struct {
+	commits int32
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{c.driver}, nil }
+
+type fakeTx struct{ driver *fakeDriver }
+
+func (tx *fakeTx) Commit() error {
+	atomic.AddInt32(&tx.driver.commits, 1)
+	return nil
+}
+func (tx *fakeTx) Rollback() error { return nil }
+
+func openFake(t *testing.T, name string) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{}
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, d
+}
+
+func TestGuard(t *testing.T) {
+	db, d := openFake(t, "dbclose-guard")
+	guard := New(db)
+
+	tx, err := guard.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rolledBack, _ := guard.BeginTx(context.Background(), nil)
+	rolledBack.Rollback()
+
+	if guard.Open() != 1 {
+		t.Errorf("Expected 1 open transaction, got %d", guard.Open())
+	}
+
+	closed := make(chan error, 1)
+	go func() { closed <- guard.Close(context.Background()) }()
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := guard.BeginTx(context.Background(), nil); err != ErrClosing {
+		t.Errorf("Expected new transactions to be blocked, got %v", err)
+	}
+	select {
+	case <-closed:
+		t.Fatal("Close should wait for the open transaction")
+	default:
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-closed; err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if atomic.LoadInt32(&d.commits) != 1 {
+		t.Error("Expected the transaction to be committed")
+	}
+	if err := db.Ping(); err == nil {
+		t.Error("Expected the pool to be closed")
+	}
+}
+
+func TestGuardDeadline(t *testing.T) {
+	db, _ := openFake(t, "dbclose-deadline")
+	guard := New(db)
+
+	done, err := guard.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := guard.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package terminator
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext carries the values of its parent but none of its
+// deadline or cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// WithDetachedContext runs the closer on a context detached from the
+// shutdown budget, for closers that must complete regardless, such as
+// committing a transaction. The closer is still bounded by the given cap,
+// which replaces its timeout. Its result data is marked as Detached.
+func WithDetachedContext(cap time.Duration) CloserOption {
+	return func(p *payload) {
+		p.Detached = true
+		p.Timeout = cap
+	}
+}
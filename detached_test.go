@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDetachedContext(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithShutdownBudget(50*time.Millisecond))
+
+	term.AddWithOptions("transaction", func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("Detached closer should be bounded by its cap")
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, WithDetachedContext(time.Second))
+
+	term.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if data := result.Result[0]; data.Status != FAILED || data.Kind != ErrorKindTimeout || data.Detached {
+		t.Errorf("slow should be cut off by the budget, got %+v", data)
+	}
+
+	if data := result.Result[1]; data.Status != SUCCESS || !data.Detached {
+		t.Errorf("transaction should complete past the budget, got %+v", data)
+	}
+}
@@ -0,0 +1,108 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDrainWindowEndsEarly(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithDrainWindow(time.Second))
+
+	parent, finish := context.WithCancel(context.Background())
+	ctx := term.RequestContext(parent)
+
+	var closedAt time.Time
+	term.Add("db", func(ctx context.Context) error {
+		closedAt = time.Now()
+		return nil
+	})
+
+	go func() {
+		<-term.Draining()
+		time.Sleep(50 * time.Millisecond)
+		finish()
+	}()
+
+	started := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(2 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if wait := closedAt.Sub(started); wait < 50*time.Millisecond || wait > 500*time.Millisecond {
+		t.Errorf("Expected the resources to be closed once the request finished, after %v", wait)
+	}
+	if ctx.Err() != context.Canceled {
+		t.Error("The request context should be done")
+	}
+}
+
+func TestDrainWindowHardCancel(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithDrainWindow(100*time.Millisecond))
+
+	ctx := term.RequestContext(context.Background())
+
+	var canceledBeforeClose bool
+	term.Add("db", func(closeCtx context.Context) error {
+		canceledBeforeClose = ctx.Err() != nil
+		return nil
+	})
+
+	started := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if elapsed := time.Since(started); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected the closers to wait for the drain window, completed after %v", elapsed)
+	}
+	if !canceledBeforeClose {
+		t.Error("Expected the request context to be canceled before the resources are closed")
+	}
+}
+
+func TestPhaseDrainWindow(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithPhaseDrainWindow(PhaseBroker, 50*time.Millisecond))
+
+	handling := term.PhaseContext(context.Background(), PhaseBroker)
+	other := term.PhaseContext(context.Background(), PhaseStorage)
+
+	var handlingCanceled, otherCanceled bool
+	var brokerWait time.Duration
+	term.Add("app", func(ctx context.Context) error {
+		if handling.Err() != nil {
+			t.Error("The phase context shouldn't be canceled before its phase")
+		}
+		return nil
+	})
+	term.AddWithOptions("broker", func(ctx context.Context) error {
+		handlingCanceled = handling.Err() != nil
+		otherCanceled = other.Err() != nil
+		return nil
+	}, InPhase(PhaseBroker))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	for _, phase := range result.SLO.Phases {
+		if phase.Phase == PhaseBroker {
+			brokerWait = phase.Duration
+		}
+	}
+
+	if !handlingCanceled || otherCanceled {
+		t.Errorf("Expected only the broker phase contexts to be canceled before its closers, got %v and %v", handlingCanceled, otherCanceled)
+	}
+	if brokerWait < 50*time.Millisecond {
+		t.Errorf("Expected the broker phase to wait for its drain window, took %v", brokerWait)
+	}
+	if other.Err() == nil {
+		t.Error("Phase contexts should be canceled once the termination completed")
+	}
+}
@@ -0,0 +1,153 @@
+package terminator
+
+import "time"
+
+// Simulation configures a DryRun.
+type Simulation struct {
+
+	// Durations simulated for the resources, by name.
+	// Resources not listed are simulated to take their whole timeout.
+	Durations map[string]time.Duration
+
+	// Deadline the termination must complete within, such as the Wait timeout.
+	// The shutdown budget is used when zero.
+	Deadline time.Duration
+}
+
+// DryRunEntry is the projected closing of a single resource.
+type DryRunEntry struct {
+
+	// Name of the resource
+	Name string
+
+	// Phase of the resource
+	Phase Phase
+
+	// Projected start and end, relative to the start of the termination
+	Start, End time.Duration
+
+	// TimedOut is set when the simulated duration exceeds the time the closer is allowed
+	TimedOut bool
+
+	// Unbounded is set when the closer has neither a simulated duration nor a timeout,
+	// in which case it is projected to take no time
+	Unbounded bool
+
+	// ExceedsDeadline is set when the closer is projected to end after the deadline
+	ExceedsDeadline bool
+
+	// Skipped is set when the closer does not run in the environment of the
+	// terminator, in which case it is projected to take no time
+	Skipped bool
+}
+
+// DryRunReport is the projected timeline of the termination.
+type DryRunReport struct {
+
+	// Projected closing of every resource, in execution order
+	Entries []DryRunEntry
+
+	// Projected duration of the whole termination
+	Total time.Duration
+
+	// Deadline the projection was checked against, zero if none
+	Deadline time.Duration
+
+	// ExceedsDeadline is set when the termination is projected to end after the deadline
+	ExceedsDeadline bool
+}
+
+// DryRun projects the timeline of the termination with simulated durations,
+// without closing anything, flagging the closers that would time out or end
+// after the deadline. It accounts for the phases, the closer timeouts and the
+// shutdown budget, as well as the parallel phases and their group limits.
+func (t *terminator) DryRun(sim Simulation) DryRunReport {
+	t.mu.Lock()
+	closers := executionOrder(t.closersStack)
+	t.mu.Unlock()
+
+	report := DryRunReport{
+		Entries:  make([]DryRunEntry, 0, len(closers)),
+		Deadline: sim.Deadline,
+	}
+	if report.Deadline == 0 {
+		report.Deadline = t.config.shutdownBudget
+	}
+
+	// Resources start once a slot of their group is free, within their phase.
+	// Closing one at a time is a single slot shared by all the resources.
+	var phaseStart, elapsed time.Duration
+	var slots map[string][]time.Duration
+	for index, closer := range closers {
+		if index == 0 || closer.Phase != closers[index-1].Phase {
+			phaseStart = elapsed
+			slots = make(map[string][]time.Duration)
+		}
+
+		group, limit := "", 1
+		if t.config.parallelPhases {
+			group, limit = closer.Group, t.config.groupLimit(&closer)
+		}
+
+		start, slot := phaseStart, 0
+		if limit > 0 {
+			if len(slots[group]) < limit {
+				slot = len(slots[group])
+				slots[group] = append(slots[group], phaseStart)
+			} else {
+				for free := range slots[group] {
+					if slots[group][free] < slots[group][slot] {
+						slot = free
+					}
+				}
+			}
+			start = slots[group][slot]
+		}
+
+		entry := DryRunEntry{Name: closer.Name, Phase: closer.Phase, Start: start}
+		if !t.config.enabled(&closer) {
+			entry.End, entry.Skipped = start, true
+			report.Entries = append(report.Entries, entry)
+			continue
+		}
+
+		duration, simulated := sim.Durations[closer.Name]
+		if !simulated {
+			duration = closer.Timeout
+			entry.Unbounded = closer.Timeout <= 0
+		}
+
+		// The closer is cut off by its own timeout, or by the shutdown budget.
+		allowed := closer.Timeout
+		if budget := t.config.shutdownBudget; budget > 0 && !closer.Detached {
+			remaining := budget - start
+			if remaining < 0 {
+				remaining = 0
+			}
+			if allowed <= 0 || remaining < allowed {
+				allowed = remaining
+			}
+		}
+
+		if (closer.Timeout > 0 || t.config.shutdownBudget > 0) && duration > allowed {
+			duration = allowed
+			entry.TimedOut = true
+		}
+
+		entry.End = start + duration
+		if limit > 0 {
+			slots[group][slot] = entry.End
+		}
+		if entry.End > elapsed {
+			elapsed = entry.End
+		}
+		entry.ExceedsDeadline = report.Deadline > 0 && entry.End > report.Deadline
+
+		report.Entries = append(report.Entries, entry)
+	}
+
+	report.Total = elapsed
+	report.ExceedsDeadline = report.Deadline > 0 && report.Total > report.Deadline
+
+	return report
+}
@@ -0,0 +1,55 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDryRun(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithShutdownBudget(10*time.Second))
+
+	closed := false
+	noop := func(ctx context.Context) error {
+		closed = true
+		return nil
+	}
+
+	term.AddWithTimeout("database", noop, 5*time.Second)
+	term.AddWithTimeout("cache", noop, 2*time.Second)
+	term.AddWithTimeout("server", noop, 4*time.Second)
+
+	report := term.DryRun(Simulation{
+		Durations: map[string]time.Duration{
+			"server": 3 * time.Second,
+			"cache":  3 * time.Second,
+		},
+		Deadline: 6 * time.Second,
+	})
+
+	if closed {
+		t.Error("DryRun shouldn't close anything")
+	}
+
+	expected := []DryRunEntry{
+		{Name: "server", Start: 0, End: 3 * time.Second},
+		{Name: "cache", Start: 3 * time.Second, End: 5 * time.Second, TimedOut: true},
+		{Name: "database", Start: 5 * time.Second, End: 10 * time.Second, ExceedsDeadline: true},
+	}
+
+	if len(report.Entries) != len(expected) {
+		t.Errorf("Expected %d entries, got %+v", len(expected), report.Entries)
+		return
+	}
+
+	for i := range expected {
+		if report.Entries[i] != expected[i] {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, expected[i], report.Entries[i])
+		}
+	}
+
+	if report.Total != 10*time.Second || !report.ExceedsDeadline {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,88 @@
+package terminator
+
+import (
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by WithEnvConfig.
+const (
+
+	// GracePeriodEnv holds the termination grace period, as a duration such
+	// as "30s" or a number of seconds, see WithGracePeriod.
+	GracePeriodEnv = "TERMINATOR_GRACE_PERIOD"
+
+	// ParallelismEnv holds the number of resources of a phase closed
+	// concurrently, 1 closing them one at a time.
+	ParallelismEnv = "TERMINATOR_PARALLELISM"
+
+	// LogLevelEnv holds the level of the logs: "off", "warn", the default,
+	// or "debug", which also logs every step of the termination.
+	LogLevelEnv = "TERMINATOR_LOG_LEVEL"
+)
+
+// WithEnvConfig lets the operators tune the termination without a redeploy
+// of the configuration, through GracePeriodEnv, ParallelismEnv and
+// LogLevelEnv. The variables set override the other options whatever their
+// order, and invalid values are ignored with a warning.
+func WithEnvConfig() Option {
+	return func(c *config) {
+		c.envConfig = true
+	}
+}
+
+// applyEnv applies the overrides of the environment variables set.
+func (c *config) applyEnv() {
+	if value, ok := os.LookupEnv(LogLevelEnv); ok {
+		switch strings.ToLower(value) {
+		case "off":
+			c.logger = log.New(io.Discard, "", 0)
+		case "warn":
+		case "debug":
+			c.debug = true
+		default:
+			c.logger.Printf("ignoring %s=%q: not one of off, warn or debug", LogLevelEnv, value)
+		}
+	}
+
+	if value, ok := os.LookupEnv(GracePeriodEnv); ok {
+		grace, err := time.ParseDuration(value)
+		if err != nil {
+			var seconds float64
+			seconds, err = strconv.ParseFloat(value, 64)
+			grace = time.Duration(seconds * float64(time.Second))
+		}
+
+		if err != nil || grace <= 0 {
+			c.logger.Printf("ignoring %s=%q: not a positive duration", GracePeriodEnv, value)
+		} else {
+			WithGracePeriod(grace)(c)
+		}
+	}
+
+	if value, ok := os.LookupEnv(ParallelismEnv); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			c.logger.Printf("ignoring %s=%q: not a positive number", ParallelismEnv, value)
+		} else {
+			WithConcurrency(n)(c)
+		}
+	}
+}
+
+// logEvent logs a step of the termination, with the debug level.
+func (t *terminator) logEvent(event Event) {
+	switch event.Kind {
+	case EventStateChanged:
+		t.config.logger.Printf("debug: %s -> %s", event.From, event.To)
+	case EventCloserFinished:
+		t.config.logger.Printf("debug: %s closed: %v in %v", event.Name, event.Data.Status, event.Data.Duration)
+	case EventCloserProgress:
+	default:
+		t.config.logger.Printf("debug: %s", strings.TrimSpace(strings.ToLower(string(event.Kind))+" "+event.Name))
+	}
+}
@@ -0,0 +1,119 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func setEnv(t *testing.T, env map[string]string) {
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	t.Cleanup(func() {
+		for key := range env {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestEnvConfig(t *testing.T) {
+	setEnv(t, map[string]string{GracePeriodEnv: "20s", ParallelismEnv: "3"})
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithEnvConfig(), WithShutdownBudget(time.Minute))
+	config := term.(*terminator).config
+
+	if config.shutdownBudget != 16*time.Second || config.watchdog != 18*time.Second {
+		t.Errorf("Expected the grace period to override the budget, got %v and %v", config.shutdownBudget, config.watchdog)
+	}
+	if !config.parallelPhases || config.concurrency != 3 {
+		t.Errorf("Expected 3 resources closed concurrently, got %v and %d", config.parallelPhases, config.concurrency)
+	}
+
+	setEnv(t, map[string]string{GracePeriodEnv: "30", ParallelismEnv: "1"})
+	config = NewTerminator([]os.Signal{os.Interrupt}, WithParallelPhases(), WithEnvConfig()).(*terminator).config
+	if config.shutdownBudget != 24*time.Second || config.parallelPhases {
+		t.Errorf("Expected a 24s budget and sequential phases, got %v and %v", config.shutdownBudget, config.parallelPhases)
+	}
+
+	config = NewTerminator([]os.Signal{os.Interrupt}).(*terminator).config
+	if config.shutdownBudget != 0 {
+		t.Error("The environment should be ignored without WithEnvConfig")
+	}
+}
+
+func TestEnvConfigInvalid(t *testing.T) {
+	setEnv(t, map[string]string{GracePeriodEnv: "soon", ParallelismEnv: "-1", LogLevelEnv: "verbose"})
+
+	logger := &recordingLogger{}
+	config := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithEnvConfig()).(*terminator).config
+
+	if len(logger.lines) != 3 {
+		t.Errorf("Expected a warning for every variable, got %q", logger.lines)
+	}
+	if config.shutdownBudget != 0 || config.parallelPhases {
+		t.Error("Expected the invalid values to be ignored")
+	}
+}
+
+func TestEnvConfigLogLevel(t *testing.T) {
+	setEnv(t, map[string]string{LogLevelEnv: "debug"})
+
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger), WithEnvConfig())
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if !strings.Contains(strings.Join(logger.lines, "\n"), "debug: db closed: SUCCESS") {
+		t.Errorf("Expected the steps to be logged, got %q", logger.lines)
+	}
+
+	setEnv(t, map[string]string{LogLevelEnv: "off", ParallelismEnv: "none"})
+	logged := len(logger.lines)
+	NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithEnvConfig())
+	if len(logger.lines) != logged {
+		t.Errorf("Expected nothing to be logged with the off level, got %q", logger.lines[logged:])
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	setEnv(t, map[string]string{ParallelismEnv: "2"})
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithEnvConfig())
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	for i := 0; i < 6; i++ {
+		term.Add("worker", func(ctx context.Context) error {
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if peak != 2 {
+		t.Errorf("Expected 2 resources closed concurrently at most, got %d", peak)
+	}
+}
@@ -0,0 +1,34 @@
+package terminator
+
+// WithEnvironment sets the environment the terminator runs in, such as
+// "production", for the resources registered WithEnvironments.
+func WithEnvironment(env string) Option {
+	return func(c *config) {
+		c.environment = env
+	}
+}
+
+// WithEnvironments restricts the closing of the resource to the given
+// environments, for teardown steps such as deregistering from service
+// discovery. In any other environment, including when the terminator has no
+// environment set with WithEnvironment, the resource is reported as SKIPPED.
+func WithEnvironments(envs ...string) CloserOption {
+	return func(p *payload) {
+		p.Environments = envs
+	}
+}
+
+// enabled reports whether the resource is closed in the environment of the terminator.
+func (c *config) enabled(closer *payload) bool {
+	if len(closer.Environments) == 0 {
+		return true
+	}
+
+	for _, env := range closer.Environments {
+		if env == c.environment {
+			return true
+		}
+	}
+
+	return false
+}
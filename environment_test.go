@@ -0,0 +1,60 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWithEnvironments(t *testing.T) {
+	for _, env := range []string{"production", "development", ""} {
+		term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithEnvironment(env))
+
+		deregistered, closed := false, false
+		term.AddWithOptions("service discovery", func(ctx context.Context) error {
+			deregistered = true
+			return nil
+		}, WithEnvironments("production", "staging"))
+		term.Add("db", func(ctx context.Context) error {
+			closed = true
+			return nil
+		})
+
+		term.(*terminator).signalChan <- os.Interrupt
+		if !term.Wait(1 * time.Second) {
+			t.Fatalf("%q: Wait shouldn't time out", env)
+		}
+
+		if !closed {
+			t.Errorf("%q: resources without environments should always be closed", env)
+		}
+
+		expected := SKIPPED
+		if env == "production" {
+			expected = SUCCESS
+		}
+
+		result, _ := term.Result()
+		if deregistered != (expected == SUCCESS) || result.Result[1].Status != expected {
+			t.Errorf("%q: expected service discovery to be %v, got %+v", env, expected, result.Result[1])
+		}
+	}
+}
+
+func TestDryRunSkipsOtherEnvironments(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithEnvironment("development"))
+	noop := func(ctx context.Context) error { return nil }
+
+	term.AddWithTimeout("db", noop, time.Second)
+	term.AddWithOptions("service discovery", noop, WithEnvironments("production"), WithCloserTimeout(time.Second))
+
+	report := term.DryRun(Simulation{})
+
+	if !report.Entries[0].Skipped || report.Entries[0].End != 0 {
+		t.Errorf("Expected service discovery to be skipped, got %+v", report.Entries[0])
+	}
+	if report.Total != time.Second {
+		t.Errorf("Expected a total of 1s, got %v", report.Total)
+	}
+}
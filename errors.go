@@ -0,0 +1,95 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+)
+
+// ErrorKind classifies the error of a resource that failed to close.
+type ErrorKind string
+
+const (
+
+	// ErrorKindNone is the kind of a nil error.
+	ErrorKindNone ErrorKind = ""
+
+	// ErrorKindTimeout indicates that closing the resource exceeded a deadline.
+	ErrorKindTimeout ErrorKind = "TIMEOUT"
+
+	// ErrorKindCanceled indicates that closing the resource was canceled.
+	ErrorKindCanceled ErrorKind = "CANCELED"
+
+	// ErrorKindIO indicates an I/O, file system or network error.
+	ErrorKindIO ErrorKind = "IO"
+
+	// ErrorKindPanic indicates that the closer panicked.
+	ErrorKindPanic ErrorKind = "PANIC"
+
+	// ErrorKindUnknown is the kind of any other error.
+	ErrorKindUnknown ErrorKind = "UNKNOWN"
+)
+
+// PanicError is the error reported for a closer that panicked.
+type PanicError struct {
+
+	// Value passed to panic
+	Value interface{}
+
+	// Stack trace of the goroutine that panicked
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("terminator: closer panicked: %v", e.Value)
+}
+
+// ClassifyError returns the kind of err, using errors.Is and errors.As so
+// wrapped errors are classified by their cause.
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindNone
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return ErrorKindPanic
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorKindTimeout
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ErrorKindCanceled
+	}
+
+	if isIOError(err) {
+		return ErrorKindIO
+	}
+
+	return ErrorKindUnknown
+}
+
+// isIOError reports whether err is caused by an I/O, file system or network failure.
+func isIOError(err error) bool {
+	for _, target := range []error{io.EOF, io.ErrUnexpectedEOF, io.ErrClosedPipe, io.ErrShortWrite, os.ErrClosed, net.ErrClosed} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	var pathErr *os.PathError
+	var linkErr *os.LinkError
+	var syscallErr *os.SyscallError
+	var opErr *net.OpError
+
+	return errors.As(err, &pathErr) || errors.As(err, &linkErr) ||
+		errors.As(err, &syscallErr) || errors.As(err, &opErr)
+}
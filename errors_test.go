@@ -0,0 +1,71 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestClassifyError(t *testing.T) {
+	_, pathErr := os.Open("/does/not/exist")
+
+	cases := []struct {
+		err  error
+		kind ErrorKind
+	}{
+		{nil, ErrorKindNone},
+		{context.DeadlineExceeded, ErrorKindTimeout},
+		{fmt.Errorf("flush: %w", context.DeadlineExceeded), ErrorKindTimeout},
+		{context.Canceled, ErrorKindCanceled},
+		{fmt.Errorf("read: %w", io.ErrUnexpectedEOF), ErrorKindIO},
+		{pathErr, ErrorKindIO},
+		{&PanicError{Value: "boom"}, ErrorKindPanic},
+		{errors.New("something else"), ErrorKindUnknown},
+	}
+
+	for _, c := range cases {
+		if kind := ClassifyError(c.err); kind != c.kind {
+			t.Errorf("%v: expected %q, got %q", c.err, c.kind, kind)
+		}
+	}
+}
+
+func TestCloserPanic(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	closed := false
+	term.Add("app1", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+	term.Add("panicky", func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	data := result.Result[0]
+	var panicErr *PanicError
+	if data.Kind != ErrorKindPanic || !errors.As(data.Error, &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("Expected the panic to be reported, got %+v", data)
+	}
+
+	if !closed {
+		t.Error("app1 should be closed after the panicking closer")
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/RohanPoojary/go-terminator
 
 go 1.20
+
+require golang.org/x/sys v0.5.0
@@ -0,0 +1,99 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+)
+
+// Go runs fn in a managed goroutine. The context passed to fn is canceled
+// when its closer runs, as configured by the options, and the closer waits
+// for fn to return. An error returned by fn is reported as the closer's
+// error, unless it is the cancellation of its context.
+// With the PanicTerminate policy, a panic in fn or an error wrapped with
+// Fatal triggers the termination of the process, and so does any error
+// with WithTerminateOnError.
+// fn is not run if the registration fails, whose error is returned. Once
+// registered, fn always runs, with a canceled context if the closer already
+// ran.
+func (t *terminator) Go(name string, fn func(context.Context) error, opts ...CloserOption) error {
+	_, err := t.goManaged(name, fn, opts...)
+	return err
+}
+
+// goManaged runs fn in a managed goroutine as Go does, also returning the
+// handle of its closer, which stops the goroutine when closed.
+func (t *terminator) goManaged(name string, fn func(context.Context) error, opts ...CloserOption) (*Handle, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	decided := make(chan struct{})
+	exited := make(chan struct{})
+
+	// fn starts once the registration returned, unless it failed.
+	var failed bool
+	var err error
+	go func() {
+		defer close(exited)
+
+		<-decided
+		if failed {
+			return
+		}
+		err = t.runManaged(ctx, fn)
+	}()
+
+	stop := func(closeCtx context.Context) error {
+		cancel()
+
+		// The closer may run before the registration returned, such as
+		// with WithCloseLateRegistrations, when fn did not start yet.
+		select {
+		case <-decided:
+		default:
+			return nil
+		}
+
+		select {
+		case <-exited:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		case <-closeCtx.Done():
+			return closeCtx.Err()
+		}
+	}
+
+	handle, regErr := t.AddWithOptions(name, stop, opts...)
+	if regErr != nil {
+		failed = true
+		cancel()
+	}
+	close(decided)
+
+	return handle, regErr
+}
+
+// runManaged runs fn with ctx, applying the panic policy and the error
+// handling of the managed goroutines, and returns its error.
+func (t *terminator) runManaged(ctx context.Context, fn func(context.Context) error) (err error) {
+	if t.config.panicPolicy == PanicTerminate {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+				t.terminate(ReasonInternalFailure, err)
+			}
+		}()
+	}
+
+	err = fn(ctx)
+
+	switch {
+	case err == nil || ctx.Err() != nil:
+	case t.config.panicPolicy == PanicTerminate && IsFatal(err):
+		t.terminate(ReasonInternalFailure, err)
+	case t.config.terminateOnError:
+		t.terminate(ReasonRoutineError, err)
+	}
+
+	return err
+}
@@ -0,0 +1,228 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGo(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	stopped := false
+	term.Go("consumer", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		stopped = true
+		return ctx.Err()
+	})
+
+	term.Go("failing", func(ctx context.Context) error {
+		return errors.New("connection lost")
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if !stopped {
+		t.Error("Closer should wait for consumer to exit")
+	}
+
+	if data := result.Result[0]; data.Name != "failing" || data.Error == nil || data.Error.Error() != "connection lost" {
+		t.Errorf("Expected the error of failing to be reported, got %+v", data)
+	}
+
+	if data := result.Result[1]; data.Name != "consumer" || data.Status != SUCCESS {
+		t.Errorf("Cancellation of consumer shouldn't be reported as an error, got %+v", data)
+	}
+}
+
+func TestGoPanicTerminates(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithPanicPolicy(PanicTerminate))
+
+	term.Go("worker", func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	if !term.Wait(2 * time.Second) {
+		t.Error("The panic should trigger the termination")
+		return
+	}
+
+	if result.Signal != nil || result.Reason != ReasonInternalFailure {
+		t.Errorf("Unexpected trigger: %v %q", result.Signal, result.Reason)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(result.Err(), &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("Expected the panic as the cause, got %v", result.Err())
+	}
+
+	if data := result.Result[0]; data.Kind != ErrorKindPanic {
+		t.Errorf("Expected the panic in the worker result, got %+v", data)
+	}
+}
+
+func TestGoFatalTerminates(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithPanicPolicy(PanicTerminate))
+
+	lost := errors.New("connection lost")
+	term.Go("worker", func(ctx context.Context) error {
+		return Fatal(lost)
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	if !term.Wait(2 * time.Second) {
+		t.Error("The fatal error should trigger the termination")
+		return
+	}
+
+	if result.Reason != ReasonInternalFailure || !errors.Is(result.Err(), lost) {
+		t.Errorf("Unexpected trigger: %q %v", result.Reason, result.Err())
+	}
+}
+
+func TestTerminateOnError(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithTerminateOnError())
+
+	stopped := make(chan bool, 1)
+	term.Go("consumer", func(ctx context.Context) error {
+		<-ctx.Done()
+		stopped <- true
+		return errors.New("stopped while consuming")
+	})
+
+	lost := errors.New("connection lost")
+	term.Go("producer", func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return lost
+	})
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	if !term.Wait(2 * time.Second) {
+		t.Error("The error should trigger the termination")
+		return
+	}
+
+	if result.Reason != ReasonRoutineError || result.Err() != lost {
+		t.Errorf("Unexpected trigger: %q %v", result.Reason, result.Err())
+	}
+
+	select {
+	case <-stopped:
+	default:
+		t.Error("consumer should be stopped")
+	}
+}
+
+func TestGoRunsOnceRegistered(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		term := NewTerminator([]os.Signal{os.Interrupt})
+
+		ran := make(chan struct{})
+		err := term.Go("consumer", func(ctx context.Context) error {
+			close(ran)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		term.(*terminator).signalChan <- os.Interrupt
+
+		if !term.Wait(time.Second) {
+			t.Fatal("Termination timed out")
+		}
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("Expected fn to run once registered, even when canceled right away")
+		}
+	}
+}
+
+func TestGoRejected(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(make(lineLogger, 16)))
+	term.Seal()
+
+	started := make(chan struct{}, 1)
+	fn := func(ctx context.Context) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil
+	}
+
+	if err := term.Go("consumer", fn); err != ErrSealed {
+		t.Errorf("Expected ErrSealed, got %v", err)
+	}
+	if h, err := term.Ticker("ticker", time.Millisecond, func(ctx context.Context) {}); err != ErrSealed || h != nil {
+		t.Errorf("Expected ErrSealed from Ticker, got %v", err)
+	}
+	if g, err := term.Group("group"); err != ErrSealed || g != nil {
+		t.Errorf("Expected ErrSealed from Group, got %v", err)
+	}
+	if child, err := term.Child("child"); err != ErrSealed || child != nil {
+		t.Errorf("Expected ErrSealed from Child, got %v", err)
+	}
+	if b, err := term.Barrier("barrier", 1); err != ErrSealed || b != nil {
+		t.Errorf("Expected ErrSealed from Barrier, got %v", err)
+	}
+	if b, err := term.JobBoundary("jobs"); err != ErrSealed || b != nil {
+		t.Errorf("Expected ErrSealed from JobBoundary, got %v", err)
+	}
+	if h, err := term.AddSteps("steps", func(ctx context.Context) (bool, error) { return true, nil }); err != ErrSealed || h != nil {
+		t.Errorf("Expected ErrSealed from AddSteps, got %v", err)
+	}
+
+	select {
+	case <-started:
+		t.Error("Expected the goroutine not to start when its registration is rejected")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestGoClosedOnRegistration(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithCloseLateRegistrations())
+
+	var lateErr error
+	term.Add("db", func(ctx context.Context) error {
+		lateErr = term.Go("late", func(ctx context.Context) error {
+			t.Error("Expected the goroutine not to start once closed")
+			return nil
+		})
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if lateErr != ErrClosedOnRegistration {
+		t.Errorf("Expected ErrClosedOnRegistration, got %v", lateErr)
+	}
+}
@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultGracePeriodEnv is the environment variable commonly holding the
+// termination grace period of the pod, in seconds, when injected from the
+// deployment manifest.
+const DefaultGracePeriodEnv = "TERMINATION_GRACE_PERIOD_SECONDS"
+
+// WithGracePeriod derives the shutdown budget and the watchdog from the
+// termination grace period of the platform, after which the process is
+// killed: the closers have 80% of it, and the watchdog exits the process
+// with code 1 at 90% of it, leaving time to flush the logs.
+func WithGracePeriod(grace time.Duration) Option {
+	return func(c *config) {
+		if grace <= 0 {
+			return
+		}
+
+		c.shutdownBudget = grace * 8 / 10
+		c.watchdog = grace * 9 / 10
+		c.watchdogExitCode = 1
+	}
+}
+
+// WithGracePeriodFromEnv applies WithGracePeriod with the grace period read
+// in seconds from the environment variable env, such as
+// DefaultGracePeriodEnv set from terminationGracePeriodSeconds in the pod
+// spec, so that the budgets do not drift from the deployment manifests.
+// Nothing is changed if the variable is not set, and a warning is logged if
+// it is not a positive number of seconds.
+func WithGracePeriodFromEnv(env string) Option {
+	return func(c *config) {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return
+		}
+
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds <= 0 {
+			c.logger.Printf("ignoring grace period %s=%q: not a positive number of seconds", env, value)
+			return
+		}
+
+		WithGracePeriod(time.Duration(seconds * float64(time.Second)))(c)
+	}
+}
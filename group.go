@@ -0,0 +1,110 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group is a group of managed goroutines, such as the consumers of a queue,
+// sharing a context like an errgroup.Group: the first error returned by a
+// member cancels the context of the group, and so does the termination,
+// whose closer waits for the members to return.
+type Group struct {
+	term *terminator
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// mu guards closed and err.
+	mu     sync.Mutex
+	closed bool
+	err    error
+}
+
+// Group registers a group of managed goroutines as a resource. It returns
+// the error of the registration, if any.
+func (t *terminator) Group(name string, opts ...CloserOption) (*Group, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &Group{term: t, ctx: ctx, cancel: cancel}
+	if _, err := t.AddWithOptions(name, g.close, opts...); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Go runs fn in a member goroutine of the group, with the context of the
+// group. Panics and errors are handled as with Terminator.Go. fn is not run
+// once the closer of the group ran.
+func (g *Group) Go(fn func(context.Context) error) {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return
+	}
+	g.wg.Add(1)
+	g.mu.Unlock()
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := g.term.runManaged(g.ctx, fn); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// Wait waits for the members of the group to return, and returns the first
+// error returned by one of them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.err
+}
+
+// Context returns the context of the group, canceled on the first error of
+// a member or once the closer of the group runs.
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// fail records the first error of a member and cancels the group.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+		g.cancel()
+	}
+	g.mu.Unlock()
+}
+
+// close cancels the group and waits for its members to return, reporting
+// the first error of a member unless it is the cancellation of the group.
+func (g *Group) close(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		if err := g.Wait(); !errors.Is(err, context.Canceled) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
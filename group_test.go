@@ -0,0 +1,73 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	g, err := term.Group("consumers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stopped int32
+	for i := 0; i < 3; i++ {
+		g.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			atomic.AddInt32(&stopped, 1)
+			return ctx.Err()
+		})
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if stopped := atomic.LoadInt32(&stopped); stopped != 3 {
+		t.Errorf("Expected the 3 members to have returned, got %d", stopped)
+	}
+	result, _ := term.Result()
+	if result.FailedOrTimeoutCount != 0 {
+		t.Errorf("Expected the cancellation not to be reported as an error, got %+v", result.Result)
+	}
+
+	var late bool
+	g.Go(func(ctx context.Context) error {
+		late = true
+		return nil
+	})
+	if g.Wait(); late {
+		t.Error("Expected members added once the group closed not to run")
+	}
+}
+
+func TestGroupFirstError(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+	g, err := term.Group("consumers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errConsumer := errors.New("consumer failed")
+	g.Go(func(ctx context.Context) error {
+		return errConsumer
+	})
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := g.Wait(); err != errConsumer {
+		t.Errorf("Expected the first error of the group, got %v", err)
+	}
+	if g.Context().Err() == nil {
+		t.Error("Expected the context of the group to be canceled")
+	}
+}
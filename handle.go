@@ -0,0 +1,228 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+
+	// ErrClosed is returned by Handle.Close for resources already closed.
+	ErrClosed = errors.New("terminator: resource already closed")
+
+	// ErrShuttingDown is returned by Handle methods and CloseNow once the
+	// termination process has started, as it owns the registered resources
+	// from then on.
+	ErrShuttingDown = errors.New("terminator: termination in progress")
+
+	// ErrNotRegistered is reported by CloseNow when no resource is registered with the name.
+	ErrNotRegistered = errors.New("terminator: resource not registered")
+
+	// ErrSealed is returned by the registration methods once the
+	// registrations are sealed, see Terminator.Seal.
+	ErrSealed = errors.New("terminator: registrations are sealed")
+
+	// ErrWaitTimeout is returned by WaitErr when the termination did not
+	// complete within the timeout.
+	ErrWaitTimeout = errors.New("terminator: wait timed out")
+
+	// ErrAborted is returned by WaitErr when the termination completed after
+	// being abandoned with Abort.
+	ErrAborted = errors.New("terminator: termination aborted")
+)
+
+// Handle is the registration of a resource, returned by Add. It keeps
+// resources that are torn down and rebuilt at runtime consistent with the
+// closers stack.
+type Handle struct {
+	term *terminator
+
+	// mu serializes Close and Reopen.
+	mu     sync.Mutex
+	closer payload
+}
+
+// Close closes the resource now, with its timeout, and removes it from the
+// closers stack. It returns the error of the closer, ErrClosed if the
+// resource was already closed, or ErrShuttingDown once the termination
+// process has started.
+func (h *Handle) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	closer, err := h.term.removeID(h.closer.id)
+	if err == ErrNotRegistered {
+		err = ErrClosed
+	}
+	if err != nil {
+		return err
+	}
+
+	termData := <-h.term.closeStack(context.Background(), &closer, false)
+	return termData.Error
+}
+
+// Reopen registers close for the rebuilt resource, with the name and
+// options of the original registration. If the resource was not closed, its
+// close function is replaced in place. It returns ErrShuttingDown once the
+// termination process has started, in which case the caller should close the
+// rebuilt resource itself, and ErrSealed for a closed resource once the
+// registrations are sealed.
+func (h *Handle) Reopen(close CloseFunc) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	closer := h.closer
+	closer.Close = close
+
+	closer, err := h.term.reopen(closer)
+	if err != nil {
+		return err
+	}
+
+	h.closer = closer
+	return nil
+}
+
+// CloseNow closes the most recently registered resource with the given name
+// immediately, with its timeout and ctx, and removes it from the closers
+// stack, for administrative actions such as closing a connection pool now.
+// The result data reports ErrNotRegistered if there is no such resource, and
+// ErrShuttingDown once the termination process has started.
+func (t *terminator) CloseNow(ctx context.Context, name string) TerminationResultData {
+	closer, err := t.remove(func(closer *payload) bool { return closer.Name == name })
+	if err != nil {
+		return TerminationResultData{Name: name, Error: err, Kind: ClassifyError(err), Status: FAILED}
+	}
+
+	return <-t.closeStack(ctx, &closer, false)
+}
+
+// Remove removes the resource from the closers stack without closing it, for
+// resources the application closes itself, such as the connection of a
+// tenant that went away. It takes constant time, so resources can be added
+// and removed at a high rate. It returns ErrClosed if the resource was
+// already removed, or ErrShuttingDown once the termination process has started.
+func (h *Handle) Remove() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := h.term.removeID(h.closer.id)
+	if err == ErrNotRegistered {
+		err = ErrClosed
+	}
+
+	return err
+}
+
+// compactThreshold is the smallest stack compacted once half of it is removed.
+const compactThreshold = 64
+
+// remove removes the most recent registration matching from the closers stack.
+func (t *terminator) remove(matching func(*payload) bool) (payload, error) {
+	t.mu.Lock()
+
+	if t.state != StateIdle {
+		t.mu.Unlock()
+		return payload{}, ErrShuttingDown
+	}
+
+	for index := len(t.closersStack) - 1; index >= 0; index-- {
+		if closer := t.closersStack[index]; !closer.isRemoved() && matching(&closer) {
+			t.removeAtLocked(index)
+			t.mu.Unlock()
+
+			t.emitRegistrations()
+			return closer, nil
+		}
+	}
+
+	t.mu.Unlock()
+	return payload{}, ErrNotRegistered
+}
+
+// removeID removes the registration with the given id from the closers stack
+// in constant time.
+func (t *terminator) removeID(id uint64) (payload, error) {
+	t.mu.Lock()
+
+	if t.state != StateIdle {
+		t.mu.Unlock()
+		return payload{}, ErrShuttingDown
+	}
+
+	index, ok := t.positions[id]
+	if !ok {
+		t.mu.Unlock()
+		return payload{}, ErrNotRegistered
+	}
+
+	closer := t.closersStack[index]
+	t.removeAtLocked(index)
+	t.mu.Unlock()
+
+	t.emitRegistrations()
+
+	return closer, nil
+}
+
+// removeAtLocked leaves a removed entry at index of the closers stack, and
+// compacts the stack once removed entries make up half of it, so that its
+// size stays bounded by twice the number of registered resources. It must
+// be called with t.mu held.
+func (t *terminator) removeAtLocked(index int) {
+	t.closersStack[index].settle()
+	delete(t.positions, t.closersStack[index].id)
+	t.closersStack[index] = payload{}
+	t.removed++
+
+	if len(t.closersStack) < compactThreshold || t.removed*2 < len(t.closersStack) {
+		return
+	}
+
+	stack := make([]payload, 0, len(t.closersStack)-t.removed)
+	for _, closer := range t.closersStack {
+		if !closer.isRemoved() {
+			t.positions[closer.id] = len(stack)
+			stack = append(stack, closer)
+		}
+	}
+	t.closersStack = stack
+	t.removed = 0
+}
+
+// isRemoved reports whether the entry of the closers stack was removed.
+func (p *payload) isRemoved() bool {
+	return p.id == 0
+}
+
+// reopen replaces the registration of the closer, or registers it anew under
+// a new id if it was removed.
+func (t *terminator) reopen(closer payload) (payload, error) {
+	t.mu.Lock()
+
+	if t.state != StateIdle {
+		t.mu.Unlock()
+		return payload{}, ErrShuttingDown
+	}
+
+	if index, ok := t.positions[closer.id]; ok {
+		t.closersStack[index] = closer
+		t.mu.Unlock()
+		return closer, nil
+	}
+	if t.sealed {
+		t.mu.Unlock()
+		return payload{}, ErrSealed
+	}
+
+	t.nextID++
+	closer.id = t.nextID
+	t.pushLocked(closer)
+	t.mu.Unlock()
+
+	t.emitRegistrations()
+
+	return closer, nil
+}
@@ -0,0 +1,215 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHandleClose(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	closed := 0
+	handle, _ := term.AddWithTimeout("pool", func(ctx context.Context) error {
+		closed++
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("expected the closer timeout")
+		}
+		return nil
+	}, time.Second)
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if err := handle.Close(); err != nil || closed != 1 {
+		t.Fatalf("Expected the pool to be closed now, got %v after %d closes", err, closed)
+	}
+	if err := handle.Close(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if closed != 1 || len(result.Result) != 1 || result.Result[0].Name != "db" {
+		t.Errorf("Expected the closed pool to be removed from the stack, got %+v", result.Result)
+	}
+}
+
+func TestHandleReopen(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var closed []string
+	closer := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			closed = append(closed, name)
+			return nil
+		}
+	}
+
+	handle, _ := term.AddWithOptions("pool", closer("pool v1"), InPhase(PhaseBroker))
+	term.Add("db", closer("db"))
+
+	if err := handle.Reopen(closer("pool v2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Reopen(closer("pool v3")); err != nil {
+		t.Fatal(err)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	expected := []string{"pool v2", "db", "pool v3"}
+	if len(closed) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, closed)
+	}
+	for i := range expected {
+		if closed[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, closed)
+			break
+		}
+	}
+
+	if err := handle.Reopen(closer("pool v4")); err != ErrShuttingDown {
+		t.Errorf("Expected ErrShuttingDown, got %v", err)
+	}
+	if err := handle.Close(); err != ErrShuttingDown {
+		t.Errorf("Expected ErrShuttingDown, got %v", err)
+	}
+}
+
+func TestCloseNow(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var closed []string
+	closer := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			closed = append(closed, name)
+			return nil
+		}
+	}
+
+	term.Add("pool", closer("pool v1"))
+	term.Add("pool", closer("pool v2"))
+	term.Add("db", closer("db"))
+
+	termData := term.CloseNow(context.Background(), "pool")
+	if termData.Status != SUCCESS || termData.Name != "pool" || len(closed) != 1 || closed[0] != "pool v2" {
+		t.Errorf("Expected the latest pool to be closed now, got %+v and %v", termData, closed)
+	}
+
+	if termData := term.CloseNow(context.Background(), "missing"); termData.Status != FAILED || termData.Error != ErrNotRegistered {
+		t.Errorf("Expected ErrNotRegistered, got %+v", termData)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if len(closed) != 3 || closed[1] != "db" || closed[2] != "pool v1" {
+		t.Errorf("Expected the remaining resources to be closed, got %v", closed)
+	}
+
+	if termData := term.CloseNow(context.Background(), "db"); termData.Error != ErrShuttingDown {
+		t.Errorf("Expected ErrShuttingDown, got %+v", termData)
+	}
+}
+
+func TestHandleRemove(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	closed := false
+	handle, _ := term.Add("tenant", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if err := handle.Remove(); err != nil || closed {
+		t.Fatalf("Expected the tenant to be removed without closing it, got %v", err)
+	}
+	if err := handle.Remove(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if closed || len(result.Result) != 1 || result.Result[0].Name != "db" {
+		t.Errorf("Expected the removed tenant to be left out, got %+v", result.Result)
+	}
+}
+
+func TestHandleChurnCompacts(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0)).(*terminator)
+	noop := func(ctx context.Context) error { return nil }
+
+	var kept []string
+	for i := 0; i < 10000; i++ {
+		handle, _ := term.Add("tenant", noop)
+		if i%1000 == 0 {
+			kept = append(kept, "tenant")
+			continue
+		}
+		if err := handle.Remove(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	term.mu.Lock()
+	size := len(term.closersStack)
+	indexed := len(term.positions)
+	term.mu.Unlock()
+
+	if size > compactThreshold || indexed != len(kept) {
+		t.Errorf("Expected the stack to stay bounded, got %d entries and %d indexed for %d resources", size, indexed, len(kept))
+	}
+
+	term.signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if result, _ := term.Result(); len(result.Result) != len(kept) {
+		t.Errorf("Expected %d resources closed, got %d", len(kept), len(result.Result))
+	}
+}
+
+func BenchmarkHandleChurn(b *testing.B) {
+	term := NewTerminator([]os.Signal{os.Interrupt}).(*terminator)
+	noop := func(ctx context.Context) error { return nil }
+
+	// A steady population of tenants, one of which is replaced every iteration.
+	handles := make([]*Handle, 1000)
+	for i := range handles {
+		handles[i], _ = term.Add("tenant", noop)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		slot := i % len(handles)
+		if err := handles[slot].Remove(); err != nil {
+			b.Fatal(err)
+		}
+		handles[slot], _ = term.Add("tenant", noop)
+	}
+	b.StopTimer()
+
+	term.mu.Lock()
+	b.ReportMetric(float64(len(term.closersStack)), "stack-entries")
+	term.mu.Unlock()
+}
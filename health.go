@@ -0,0 +1,21 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import "net/http"
+
+// ReadinessHandler returns an http.Handler to be used as a readiness probe.
+// It responds with 200 OK once Ready was called on term, and with 503 Service
+// Unavailable before that or once the termination process has started.
+func ReadinessHandler(term Terminator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !term.IsReady() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ready\n"))
+	})
+}
@@ -0,0 +1,44 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	handler := ReadinessHandler(term)
+
+	probe := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return rec.Code
+	}
+
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 before Ready, got %d", code)
+	}
+
+	term.Ready()
+	if code := probe(); code != http.StatusOK {
+		t.Errorf("Expected 200 after Ready, got %d", code)
+	}
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 after termination, got %d", code)
+	}
+}
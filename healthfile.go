@@ -0,0 +1,65 @@
+package terminator
+
+import "os"
+
+// WithHealthFile maintains a sentinel file for file-based health checks, such
+// as a Docker HEALTHCHECK running test -f: the file is created on Ready and
+// removed as the termination starts. A file left over by a previous run is
+// removed when the terminator is created.
+func WithHealthFile(path string) Option {
+	return WithHealthFileContents(path, "ready\n", "")
+}
+
+// WithHealthFileContents is like WithHealthFile for health checks reading the
+// file rather than testing its existence: it is written with healthy on Ready
+// and rewritten with unhealthy as the termination starts, or when the
+// terminator is created. An empty unhealthy content removes the file instead.
+func WithHealthFileContents(path, healthy, unhealthy string) Option {
+	return func(c *config) {
+		c.healthFile = path
+		c.healthyContent = healthy
+		c.unhealthyContent = unhealthy
+	}
+}
+
+// markHealthy writes the healthy content to the health file, if any, unless
+// the termination has started.
+func (t *terminator) markHealthy() {
+	if t.config.healthFile == "" {
+		return
+	}
+
+	t.healthMu.Lock()
+	defer t.healthMu.Unlock()
+
+	if t.IsShuttingDown() {
+		return
+	}
+
+	if err := os.WriteFile(t.config.healthFile, []byte(t.config.healthyContent), 0o644); err != nil {
+		t.config.logger.Printf("marking the health file %s healthy: %v", t.config.healthFile, err)
+	}
+}
+
+// markUnhealthy removes the health file, if any, or rewrites it with the unhealthy content.
+func (t *terminator) markUnhealthy() {
+	if t.config.healthFile == "" {
+		return
+	}
+
+	t.healthMu.Lock()
+	defer t.healthMu.Unlock()
+
+	var err error
+	if t.config.unhealthyContent == "" {
+		if err = os.Remove(t.config.healthFile); os.IsNotExist(err) {
+			err = nil
+		}
+	} else {
+		err = os.WriteFile(t.config.healthFile, []byte(t.config.unhealthyContent), 0o644)
+	}
+
+	if err != nil {
+		t.config.logger.Printf("marking the health file %s unhealthy: %v", t.config.healthFile, err)
+	}
+}
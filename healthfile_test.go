@@ -0,0 +1,90 @@
+package terminator
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHealthFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "healthy")
+
+	if err := ioutil.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithHealthFile(path))
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the health file left over to be removed")
+	}
+
+	term.Ready()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the health file to be created on Ready: %v", err)
+	}
+
+	var existed bool
+	term.Add("server", func(ctx context.Context) error {
+		_, err := os.Stat(path)
+		existed = err == nil
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if existed {
+		t.Error("Expected the health file to be removed before closing the resources")
+	}
+
+	term.Ready()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Ready shouldn't create the health file once the termination started")
+	}
+}
+
+func TestHealthFileContents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "health")
+
+	readHealth := func() string {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Reading the health file: %v", err)
+		}
+		return string(data)
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithHealthFileContents(path, "ok", "draining"))
+	if health := readHealth(); health != "draining" {
+		t.Errorf("Expected the health file to be unhealthy until Ready, got %q", health)
+	}
+
+	term.Ready()
+	if health := readHealth(); health != "ok" {
+		t.Errorf("Expected the health file to be healthy once ready, got %q", health)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if health := readHealth(); health != "draining" {
+		t.Errorf("Expected the health file to be rewritten, got %q", health)
+	}
+}
@@ -0,0 +1,33 @@
+package terminator
+
+import "time"
+
+// DefaultInstantClose is the duration under which a heavy resource is
+// reported as closed suspiciously fast, see Heavy.
+const DefaultInstantClose = time.Millisecond
+
+// Heavy flags the resource as heavy to close, such as a database pool or a
+// message producer flushing its buffers. A heavy resource closed
+// successfully in less than DefaultInstantClose is logged as suspicious, as
+// it often means that the closer closed the wrong thing or that a no-op stub
+// was left in place.
+func Heavy() CloserOption {
+	return HeavyAtLeast(DefaultInstantClose)
+}
+
+// HeavyAtLeast flags the resource as heavy like Heavy, with the duration
+// under which it is reported as closed suspiciously fast.
+func HeavyAtLeast(min time.Duration) CloserOption {
+	return func(p *payload) {
+		p.MinDuration = min
+	}
+}
+
+// lintInstantClose logs a warning if the heavy resource was closed suspiciously fast.
+func (t *terminator) lintInstantClose(closer *payload, termData TerminationResultData) {
+	if closer.MinDuration <= 0 || termData.Status != SUCCESS || termData.Duration >= closer.MinDuration {
+		return
+	}
+
+	t.config.logger.Printf("heavy resource %q closed in %v, under %v: check that its closer is not a no-op", closer.Name, termData.Duration, closer.MinDuration)
+}
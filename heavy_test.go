@@ -0,0 +1,30 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeavyInstantClose(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger))
+
+	term.AddWithOptions("stub pool", func(ctx context.Context) error { return nil }, Heavy())
+	term.AddWithOptions("producer", func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}, HeavyAtLeast(10*time.Millisecond))
+	term.Add("cache", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], `"stub pool"`) {
+		t.Errorf("Expected only the stub pool to be reported, got %v", logger.lines)
+	}
+}
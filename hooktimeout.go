@@ -0,0 +1,66 @@
+package terminator
+
+import "time"
+
+// Names of the hooks reported in TerminationResult.HungHooks.
+const (
+	HookCallback   = "callback"
+	HookBeforeEach = "before-each"
+)
+
+// WithHookTimeout bounds the time the callback and the BeforeEach hook have
+// to return, so that a blocking hook cannot consume the remaining grace
+// period invisibly. A hook that does not return in time is left running, is
+// logged and is reported in the HungHooks of the result. The termination
+// then proceeds as if the callback returned, and closes the resource with
+// the timeout it was registered with if the BeforeEach hook hung.
+func WithHookTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.hookTimeout = d
+	}
+}
+
+// runHook runs the hook named name within the hook timeout, if any. It
+// reports false if the hook did not return in time.
+func (t *terminator) runHook(name string, hook func()) bool {
+	if t.config.hookTimeout <= 0 {
+		hook()
+		return true
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hook()
+	}()
+
+	timer := time.NewTimer(t.config.hookTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+	}
+
+	t.config.logger.Printf("%s hook did not return within %v, proceeding without it", name, t.config.hookTimeout)
+
+	t.mu.Lock()
+	if t.result != nil && !containsHook(t.result.HungHooks, name) {
+		t.result.HungHooks = append(t.result.HungHooks, name)
+	}
+	t.mu.Unlock()
+
+	return false
+}
+
+// containsHook reports whether hooks contains name.
+func containsHook(hooks []string, name string) bool {
+	for _, hook := range hooks {
+		if hook == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHookTimeoutCallback(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger), WithHookTimeout(50*time.Millisecond))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	release := make(chan struct{})
+	defer close(release)
+	term.SetCallback(func(result TerminationResult) {
+		<-release
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("A hung callback shouldn't block the termination")
+	}
+
+	result, _ := term.Result()
+	if len(result.HungHooks) != 1 || result.HungHooks[0] != HookCallback {
+		t.Errorf("Expected the callback to be reported, got %v", result.HungHooks)
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("Expected the hung callback to be logged, got %v", logger.lines)
+	}
+}
+
+func TestHookTimeoutBeforeEach(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	hook := func(info CloserInfo) time.Duration {
+		if info.Name == "stuck" {
+			<-release
+		}
+		return time.Millisecond
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithHookTimeout(20*time.Millisecond), WithBeforeEach(hook))
+	term.AddWithTimeout("stuck", func(ctx context.Context) error { return nil }, time.Second)
+	term.AddWithTimeout("db", func(ctx context.Context) error { return nil }, time.Second)
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Timeout != time.Millisecond || result.Result[1].Timeout != time.Second {
+		t.Errorf("Expected the registered timeout when the hook hangs, got %+v", result.Result)
+	}
+	if len(result.HungHooks) != 1 || result.HungHooks[0] != HookBeforeEach {
+		t.Errorf("Expected the BeforeEach hook to be reported, got %v", result.HungHooks)
+	}
+}
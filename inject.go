@@ -0,0 +1,54 @@
+package terminator
+
+import (
+	"os"
+
+	"github.com/RohanPoojary/go-terminator/internal/inject"
+)
+
+func init() {
+	inject.Signal = injectSignal
+	inject.WaitTimeout = injectWaitTimeout
+	inject.FireWatchdog = injectFireWatchdog
+}
+
+// injectSignal delivers sig to the terminator as if it was received from the
+// operating system. It reports false if t is not a terminator of this
+// package or a termination signal is already pending.
+func injectSignal(t interface{}, sig os.Signal) bool {
+	term, ok := t.(*terminator)
+	if !ok {
+		return false
+	}
+
+	select {
+	case term.signalChan <- sig:
+		return true
+	default:
+		return false
+	}
+}
+
+// injectWaitTimeout runs the timeout path of Wait on t. It reports false if t
+// is not a terminator of this package.
+func injectWaitTimeout(t interface{}) bool {
+	term, ok := t.(*terminator)
+	if !ok {
+		return false
+	}
+
+	term.waitTimedOut()
+	return true
+}
+
+// injectFireWatchdog fires the watchdog of t with exit. It reports false if
+// t is not a terminator of this package with a watchdog, or the termination
+// is done.
+func injectFireWatchdog(t interface{}, exit func(code int)) bool {
+	term, ok := t.(*terminator)
+	if !ok || term.config.watchdog <= 0 {
+		return false
+	}
+
+	return term.fireWatchdog(exit)
+}
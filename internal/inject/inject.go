@@ -0,0 +1,20 @@
+// Package inject bridges the terminator internals to its test helper and
+// integration packages without exposing them as part of the public API.
+package inject
+
+import "os"
+
+// Signal delivers sig to the terminator t as if it had been received from
+// the operating system. It is set by the terminator package on init and
+// reports whether the signal was accepted.
+var Signal func(t interface{}, sig os.Signal) bool
+
+// WaitTimeout runs what Wait does when it times out on the terminator t,
+// without waiting. It is set by the terminator package on init and reports
+// whether t is a terminator of this package.
+var WaitTimeout func(t interface{}) bool
+
+// FireWatchdog runs the watchdog of the terminator t now, calling exit
+// instead of exiting the process. It is set by the terminator package on init
+// and reports whether the watchdog fired.
+var FireWatchdog func(t interface{}, exit func(code int)) bool
@@ -0,0 +1,35 @@
+package terminator
+
+import (
+	"math/rand"
+	"time"
+)
+
+// jitterRand returns a random duration in [0, n), replaced in tests.
+var jitterRand = func(n int64) int64 { return rand.Int63n(n) }
+
+// WithShutdownJitter waits a random delay, up to max, before closing any
+// resource, so that the instances of a fleet restarted together do not hit
+// shared dependencies, such as the service registry or the database, at the
+// same instant. The delay counts towards the duration of the termination, so
+// it should stay well below the grace period of the orchestrator.
+func WithShutdownJitter(max time.Duration) Option {
+	return func(c *config) {
+		c.shutdownJitter = max
+	}
+}
+
+// waitJitter waits the shutdown jitter, if any, or until the termination is aborted.
+func (t *terminator) waitJitter() {
+	if t.config.shutdownJitter <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(time.Duration(jitterRand(int64(t.config.shutdownJitter))))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-t.abortChan:
+	}
+}
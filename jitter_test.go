@@ -0,0 +1,57 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestShutdownJitter(t *testing.T) {
+	defer func(original func(int64) int64) { jitterRand = original }(jitterRand)
+
+	var bound int64
+	jitterRand = func(n int64) int64 {
+		bound = n
+		return int64(100 * time.Millisecond)
+	}
+
+	var closedAt time.Time
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithShutdownJitter(time.Second))
+	term.Add("db", func(ctx context.Context) error {
+		closedAt = time.Now()
+		return nil
+	})
+
+	triggeredAt := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if bound != int64(time.Second) {
+		t.Errorf("Expected the jitter to be bounded by 1s, got %v", time.Duration(bound))
+	}
+	if delay := closedAt.Sub(triggeredAt); delay < 100*time.Millisecond {
+		t.Errorf("Expected the resources to be closed after the jitter, got %v", delay)
+	}
+}
+
+func TestShutdownJitterAbort(t *testing.T) {
+	defer func(original func(int64) int64) { jitterRand = original }(jitterRand)
+	jitterRand = func(n int64) int64 { return n - 1 }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithShutdownJitter(time.Hour))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	time.Sleep(50 * time.Millisecond)
+	term.Abort()
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Abort should cut the jitter short")
+	}
+	if term.State() != StateAborted {
+		t.Errorf("Expected the ABORTED state, got %v", term.State())
+	}
+}
@@ -0,0 +1,69 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+)
+
+// JobBoundary lets batch workers finish the item at hand and exit at a clean
+// boundary, instead of being interrupted midway. When its closer runs, the
+// workers are told to stop with ShouldStop and StopCh, and the closer
+// completes once every worker that joined reported it stopped.
+type JobBoundary struct {
+	tracker *Tracker
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// JobBoundary registers a job boundary as a resource. It returns the error of
+// the registration, if any.
+func (t *terminator) JobBoundary(name string, opts ...CloserOption) (*JobBoundary, error) {
+	b := &JobBoundary{
+		tracker:  NewTracker(),
+		stopChan: make(chan struct{}),
+	}
+	if _, err := t.AddWithOptions(name, b.close, opts...); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Join registers a worker, which must call Stopped once it exits. It
+// reports false once the boundary is closed, in which case the worker
+// should not start.
+func (b *JobBoundary) Join() bool {
+	return b.tracker.Begin()
+}
+
+// Stopped reports that a worker registered with Join exited.
+func (b *JobBoundary) Stopped() {
+	b.tracker.Done()
+}
+
+// ShouldStop reports whether the workers should exit, to be checked between
+// the items of a batch.
+func (b *JobBoundary) ShouldStop() bool {
+	select {
+	case <-b.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// StopCh returns a channel closed once the workers should exit, for workers
+// waiting for their next item.
+func (b *JobBoundary) StopCh() <-chan struct{} {
+	return b.stopChan
+}
+
+// close tells the workers to stop and waits for them to exit.
+func (b *JobBoundary) close(ctx context.Context) error {
+	b.stopOnce.Do(func() {
+		close(b.stopChan)
+	})
+
+	return b.tracker.Drain(ctx)
+}
@@ -0,0 +1,90 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobBoundary(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	jobs, err := term.JobBoundary("batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	processed := map[int]int{}
+	items := make(chan int)
+
+	for worker := 0; worker < 3; worker++ {
+		if !jobs.Join() {
+			t.Fatal("Workers should join before the termination")
+		}
+		go func(worker int) {
+			defer jobs.Stopped()
+			for !jobs.ShouldStop() {
+				select {
+				case item := <-items:
+					time.Sleep(10 * time.Millisecond)
+					mu.Lock()
+					processed[item]++
+					mu.Unlock()
+				case <-jobs.StopCh():
+					return
+				}
+			}
+		}(worker)
+	}
+
+	for item := 0; item < 5; item++ {
+		items <- item
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Status != SUCCESS {
+		t.Errorf("Expected the workers to stop, got %v", result.Result[0].Error)
+	}
+	mu.Lock()
+	if len(processed) != 5 {
+		t.Errorf("Expected every item handed out to be processed, got %v", processed)
+	}
+	mu.Unlock()
+
+	if jobs.Join() {
+		t.Error("Workers shouldn't join once the boundary is closed")
+	}
+}
+
+func TestJobBoundaryDeadline(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	jobs, err := term.JobBoundary("batch", WithCloserTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	jobs.Join()
+	go func() {
+		defer jobs.Stopped()
+		<-release
+	}()
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Error != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", result.Result[0].Error)
+	}
+}
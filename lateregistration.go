@@ -0,0 +1,35 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrClosedOnRegistration is returned by the registration methods, with
+// WithCloseLateRegistrations, for resources registered once the termination
+// started closing the resources: the resource was closed before it was
+// returned. It wraps ErrSealed.
+var ErrClosedOnRegistration = fmt.Errorf("%w: resource closed on registration", ErrSealed)
+
+// WithCloseLateRegistrations closes the resources registered once the
+// termination started closing the resources right away, within their
+// timeout, instead of only rejecting them with ErrSealed, for instance for
+// connections opened by requests still in flight. The registration returns
+// ErrClosedOnRegistration once the resource is closed. Registrations
+// rejected after Seal, before the termination, are not closed.
+func WithCloseLateRegistrations() Option {
+	return func(c *config) {
+		c.closeLateRegistrations = true
+	}
+}
+
+// closeLate closes a resource registered once the termination started
+// closing the resources, logging on failure.
+func (t *terminator) closeLate(closer payload) error {
+	termData := <-t.closeStack(context.Background(), &closer, false)
+	if termData.Error != nil {
+		t.config.logger.Printf("resource %q registered during the termination failed to close: %v", closer.Name, termData.Error)
+	}
+
+	return ErrClosedOnRegistration
+}
@@ -0,0 +1,42 @@
+package terminator
+
+import "context"
+
+// Resigner gives up a leadership, such as by releasing an etcd election, a
+// Consul lock or a Kubernetes Lease, to be implemented over the client of
+// the election in use.
+type Resigner interface {
+	Resign(ctx context.Context) error
+}
+
+// ResignFunc adapts a function to a Resigner.
+type ResignFunc func(ctx context.Context) error
+
+// Resign implements Resigner.
+func (f ResignFunc) Resign(ctx context.Context) error {
+	return f(ctx)
+}
+
+// WithLeaderResign resigns leadership as the very first step of the
+// termination, in PhaseAnnounce before the announcers, so that another
+// instance takes over while this one drains rather than once it exited.
+// The resignation has the timeout of the announcers, see WithAnnounceTimeout,
+// and does not count as a registered resource.
+func WithLeaderResign(name string, leader Resigner) Option {
+	return func(c *config) {
+		c.resigners = append(c.resigners, announcer{name: name, announce: leader.Resign})
+	}
+}
+
+// pushResigners adds the resignations to the closers stack, after the
+// announcers so that they run first.
+func (t *terminator) pushResigners() {
+	for _, r := range t.config.resigners {
+		t.pushHook(payload{
+			Name:    r.name,
+			Close:   r.announce,
+			Timeout: t.config.announceTimeout,
+			Phase:   PhaseAnnounce,
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLeaderResign(t *testing.T) {
+	var order []string
+	record := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithAnnouncer("registry", record("registry")),
+		WithLeaderResign("leader lease", ResignFunc(record("leader lease"))))
+	term.AddWithOptions("ticker", record("ticker"), InPhase(PhaseBackground))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	expected := []string{"leader lease", "registry", "ticker"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+
+	if registered := term.Status().Registered; registered != 1 {
+		t.Errorf("The resignation shouldn't count as a registered resource, got %d", registered)
+	}
+}
@@ -0,0 +1,14 @@
+package terminator
+
+import (
+	"log"
+	"os"
+)
+
+// Logger is used by the terminator to report warnings. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// defaultLogger writes to the standard error.
+var defaultLogger Logger = log.New(os.Stderr, "terminator: ", log.LstdFlags)
@@ -0,0 +1,49 @@
+package terminator
+
+import "context"
+
+// LongPolls tracks the long-poll handlers of a service, which would otherwise
+// keep their requests pinned for their whole poll interval during the drain.
+// The handlers are told to respond right away as the termination starts, and
+// its closer waits for them to finish.
+type LongPolls struct {
+	tracker  *Tracker
+	draining <-chan struct{}
+}
+
+// LongPolls registers a registry of long-poll handlers as a resource, closed
+// once they all returned. It returns the error of the registration, if any.
+func (t *terminator) LongPolls(name string, opts ...CloserOption) (*LongPolls, error) {
+	polls := &LongPolls{
+		tracker:  NewTracker(),
+		draining: t.Draining(),
+	}
+	if _, err := t.AddWithOptions(name, polls.close, opts...); err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+// Begin registers a long-poll handler, which must call end once it returns.
+// The handler responds, with Respond or a payload of its own, as soon as
+// shutdown is closed, that is once the termination started. It reports false
+// once the registry is closed, in which case the handler should respond
+// right away.
+func (p *LongPolls) Begin() (shutdown <-chan struct{}, end func(), ok bool) {
+	if !p.tracker.Begin() {
+		return p.draining, func() {}, false
+	}
+
+	return p.draining, p.tracker.Done, true
+}
+
+// Active returns the number of long-poll handlers registered.
+func (p *LongPolls) Active() int {
+	return p.tracker.Active()
+}
+
+// close waits for the handlers to finish.
+func (p *LongPolls) close(ctx context.Context) error {
+	return p.tracker.Drain(ctx)
+}
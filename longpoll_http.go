@@ -0,0 +1,14 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import "net/http"
+
+// Respond writes the default response of a long poll cut short by the
+// termination: 503 Service Unavailable with a Retry-After header, so that
+// clients poll again, likely reaching another instance.
+func (p *LongPolls) Respond(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, "server shutting down", http.StatusServiceUnavailable)
+}
@@ -0,0 +1,66 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLongPolls(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	polls, err := term.LongPolls("long polls", InPhase(PhaseServer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	polling := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shutdown, end, ok := polls.Begin()
+		defer end()
+		if !ok {
+			polls.Respond(w)
+			return
+		}
+
+		close(polling)
+		select {
+		case <-shutdown:
+			polls.Respond(w)
+		case <-time.After(time.Minute):
+			w.Write([]byte("no updates\n"))
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/updates", nil))
+		close(served)
+	}()
+	<-polling
+
+	if polls.Active() != 1 {
+		t.Errorf("Expected 1 long poll, got %d", polls.Active())
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("The long poll should be cut short")
+	}
+	<-served
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected a 503 response with Retry-After, got %d %v", rec.Code, rec.Header())
+	}
+
+	late := httptest.NewRecorder()
+	handler.ServeHTTP(late, httptest.NewRequest("GET", "/updates", nil))
+	if late.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected the polls started once closed to be answered right away, got %d", late.Code)
+	}
+}
@@ -0,0 +1,184 @@
+// Package metrics exposes the termination in the Prometheus text format,
+// without depending on the Prometheus client: the time every resource took
+// to close, as histograms labeled with the name of the resource, and the
+// fraction of the shutdown budget consumed, so that dashboards aggregating
+// the fleet show which resources chronically eat the grace period.
+//
+// A Collector is fed by the event handler of the terminator:
+//
+//	collector := metrics.New(20*time.Second, nil)
+//	term := terminator.NewTerminator(signals,
+//		terminator.WithShutdownBudget(20*time.Second),
+//		terminator.WithEventHandler(collector.Handle))
+//	http.Handle("/metrics/shutdown", collector)
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// DefaultBuckets are the upper bounds of the duration histograms, in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Collector accumulates the metrics of the termination from its events.
+// It is safe for concurrent use.
+type Collector struct {
+	budget  time.Duration
+	buckets []float64
+
+	mu        sync.Mutex
+	started   time.Time
+	consumed  float64
+	resources map[string]*resource
+}
+
+// resource holds the metrics of the resources sharing a name.
+type resource struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+
+	// budgetRatio is the largest fraction of the budget taken by a single
+	// close, and allocBytes the most bytes allocated while closing.
+	budgetRatio float64
+	allocBytes  uint64
+	accounted   bool
+}
+
+// New creates a collector measuring against budget, the shutdown budget
+// set with WithShutdownBudget or derived from the grace period, with the
+// given histogram buckets, DefaultBuckets if nil.
+func New(budget time.Duration, buckets []float64) *Collector {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	return &Collector{
+		budget:    budget,
+		buckets:   buckets,
+		resources: make(map[string]*resource),
+	}
+}
+
+// Handle records an event, to be set with WithEventHandler. The allocation
+// metrics are only reported with WithResourceAccounting.
+func (c *Collector) Handle(event terminator.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if event.Kind == terminator.EventShutdownStarted {
+		c.started = event.Time
+	}
+	if !c.started.IsZero() && c.budget > 0 {
+		c.consumed = float64(event.Time.Sub(c.started)) / float64(c.budget)
+	}
+
+	if event.Kind != terminator.EventCloserFinished || event.Data == nil {
+		return
+	}
+
+	r, ok := c.resources[event.Name]
+	if !ok {
+		r = &resource{counts: make([]uint64, len(c.buckets))}
+		c.resources[event.Name] = r
+	}
+
+	seconds := event.Data.Duration.Seconds()
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			r.counts[i]++
+		}
+	}
+	r.count++
+	r.sum += seconds
+
+	if c.budget > 0 {
+		if ratio := float64(event.Data.Duration) / float64(c.budget); ratio > r.budgetRatio {
+			r.budgetRatio = ratio
+		}
+	}
+	if usage := event.Data.Usage; usage != nil {
+		r.accounted = true
+		if usage.TotalAlloc > r.allocBytes {
+			r.allocBytes = usage.TotalAlloc
+		}
+	}
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.resources))
+	for name := range c.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+
+	b.WriteString("# HELP terminator_closer_duration_seconds Time taken to close the resource.\n")
+	b.WriteString("# TYPE terminator_closer_duration_seconds histogram\n")
+	for _, name := range names {
+		r, label := c.resources[name], escape(name)
+		for i, bound := range c.buckets {
+			fmt.Fprintf(&b, "terminator_closer_duration_seconds_bucket{resource=\"%s\",le=\"%s\"} %d\n", label, formatFloat(bound), r.counts[i])
+		}
+		fmt.Fprintf(&b, "terminator_closer_duration_seconds_bucket{resource=\"%s\",le=\"+Inf\"} %d\n", label, r.count)
+		fmt.Fprintf(&b, "terminator_closer_duration_seconds_sum{resource=\"%s\"} %s\n", label, formatFloat(r.sum))
+		fmt.Fprintf(&b, "terminator_closer_duration_seconds_count{resource=\"%s\"} %d\n", label, r.count)
+	}
+
+	if c.budget > 0 {
+		b.WriteString("# HELP terminator_closer_budget_ratio Largest fraction of the shutdown budget taken to close the resource.\n")
+		b.WriteString("# TYPE terminator_closer_budget_ratio gauge\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "terminator_closer_budget_ratio{resource=\"%s\"} %s\n", escape(name), formatFloat(c.resources[name].budgetRatio))
+		}
+
+		b.WriteString("# HELP terminator_shutdown_budget_consumed_ratio Fraction of the shutdown budget consumed since the resources started being closed.\n")
+		b.WriteString("# TYPE terminator_shutdown_budget_consumed_ratio gauge\n")
+		fmt.Fprintf(&b, "terminator_shutdown_budget_consumed_ratio %s\n", formatFloat(c.consumed))
+	}
+
+	header := false
+	for _, name := range names {
+		if r := c.resources[name]; r.accounted {
+			if !header {
+				b.WriteString("# HELP terminator_closer_alloc_bytes Most bytes allocated while closing the resource.\n")
+				b.WriteString("# TYPE terminator_closer_alloc_bytes gauge\n")
+				header = true
+			}
+			fmt.Fprintf(&b, "terminator_closer_alloc_bytes{resource=\"%s\"} %d\n", escape(name), r.allocBytes)
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// escape escapes a label value.
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats a sample value.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+	"github.com/RohanPoojary/go-terminator/terminatortest"
+)
+
+func TestCollector(t *testing.T) {
+	collector := New(time.Second, []float64{0.01, 10})
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt}, terminator.WithRegistrationGrace(0),
+		terminator.WithResourceAccounting(), terminator.WithEventHandler(collector.Handle))
+
+	term.Add("cache", func(ctx context.Context) error { return nil })
+	term.Add(`db "primary"`, func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	terminatortest.InjectSignal(term, os.Interrupt)
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, expected := range []string{
+		"# TYPE terminator_closer_duration_seconds histogram\n",
+		`terminator_closer_duration_seconds_bucket{resource="db \"primary\"",le="0.01"} 0` + "\n",
+		`terminator_closer_duration_seconds_bucket{resource="db \"primary\"",le="10"} 1` + "\n",
+		`terminator_closer_duration_seconds_bucket{resource="cache",le="+Inf"} 1` + "\n",
+		`terminator_closer_duration_seconds_count{resource="cache"} 1` + "\n",
+		`terminator_closer_budget_ratio{resource="db \"primary\""} 0.0`,
+		"terminator_shutdown_budget_consumed_ratio 0.0",
+		`terminator_closer_alloc_bytes{resource="cache"} `,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %q in\n%s", expected, body)
+		}
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestCollectorWithoutBudget(t *testing.T) {
+	collector := New(0, nil)
+	collector.Handle(terminator.Event{Kind: terminator.EventShutdownStarted, Time: time.Now()})
+	collector.Handle(terminator.Event{
+		Kind: terminator.EventCloserFinished,
+		Time: time.Now(),
+		Name: "db",
+		Data: &terminator.TerminationResultData{Name: "db", Status: terminator.SUCCESS, Duration: 3 * time.Second},
+	})
+
+	var b strings.Builder
+	collector.WriteTo(&b)
+
+	if strings.Contains(b.String(), "budget") || strings.Contains(b.String(), "alloc") {
+		t.Errorf("Expected neither budget nor allocation metrics, got\n%s", b.String())
+	}
+	if !strings.Contains(b.String(), `terminator_closer_duration_seconds_bucket{resource="db",le="2.5"} 0`) ||
+		!strings.Contains(b.String(), `terminator_closer_duration_seconds_sum{resource="db"} 3`) {
+		t.Errorf("Unexpected histogram\n%s", b.String())
+	}
+}
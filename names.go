@@ -0,0 +1,132 @@
+package terminator
+
+import (
+	"context"
+	"path"
+	"strings"
+)
+
+// NameSeparator separates the levels of hierarchical resource names, such
+// as "db/primary" and "db/replica/1", matched by MatchName.
+const NameSeparator = "/"
+
+// MatchName reports whether the resource name matches pattern, level by
+// level: the levels of the pattern are matched as with path.Match, so that
+// "*" matches a whole level, and a "**" level matches any number of levels,
+// so that "db/replica/*" matches "db/replica/1" and "db/**" matches every
+// resource under "db". It returns path.ErrBadPattern for malformed patterns.
+func MatchName(pattern, name string) (bool, error) {
+	patterns := strings.Split(pattern, NameSeparator)
+	for _, level := range patterns {
+		if _, err := path.Match(level, ""); err != nil {
+			return false, err
+		}
+	}
+
+	return matchLevels(patterns, strings.Split(name, NameSeparator)), nil
+}
+
+// matchLevels matches the levels of a name with the levels of a valid pattern.
+func matchLevels(patterns, levels []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			for skipped := 0; skipped <= len(levels); skipped++ {
+				if matchLevels(patterns[1:], levels[skipped:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(levels) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(patterns[0], levels[0]); !ok {
+			return false
+		}
+		patterns, levels = patterns[1:], levels[1:]
+	}
+
+	return len(levels) == 0
+}
+
+// RemoveMatching removes every resource whose name matches pattern, see
+// MatchName, from the closers stack without closing them, like Handle.Remove,
+// and returns the number of resources removed. It returns ErrShuttingDown
+// once the termination process has started.
+func (t *terminator) RemoveMatching(pattern string) (int, error) {
+	closers, err := t.removeMatching(pattern)
+	return len(closers), err
+}
+
+// CloseMatching closes every resource whose name matches pattern, see
+// MatchName, in the order of the termination, one at a time with ctx and
+// their timeouts, and removes them from the closers stack, for partial
+// shutdowns such as closing all the replicas of a database. It returns
+// their result data, or ErrShuttingDown once the termination process has
+// started.
+func (t *terminator) CloseMatching(ctx context.Context, pattern string) ([]TerminationResultData, error) {
+	closers, err := t.removeMatching(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]TerminationResultData, 0, len(closers))
+	for _, closer := range executionOrder(closers) {
+		results = append(results, <-t.closeStack(ctx, &closer, false))
+	}
+
+	return results, nil
+}
+
+// removeMatching removes the resources whose name matches pattern, leaving
+// the closers configured through the options, and returns them in the order
+// of the closers stack.
+func (t *terminator) removeMatching(pattern string) ([]payload, error) {
+	if _, err := MatchName(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+
+	if t.state != StateIdle {
+		t.mu.Unlock()
+		return nil, ErrShuttingDown
+	}
+
+	var matching []payload
+	for _, closer := range t.closersStack {
+		if closer.isRemoved() || t.isHookLocked(&closer) {
+			continue
+		}
+		if ok, _ := MatchName(pattern, closer.Name); ok {
+			matching = append(matching, closer)
+		}
+	}
+
+	// Removals may compact the stack, so the closers are looked up by id.
+	for _, closer := range matching {
+		t.removeAtLocked(t.positions[closer.id])
+	}
+	t.mu.Unlock()
+
+	if len(matching) > 0 {
+		t.emitRegistrations()
+	}
+
+	return matching, nil
+}
+
+// Filter returns the result data of the resources whose name matches
+// pattern, see MatchName, such as all the replicas of a database. Malformed
+// patterns match nothing.
+func (r TerminationResult) Filter(pattern string) []TerminationResultData {
+	var filtered []TerminationResultData
+	for _, termData := range r.Result {
+		if ok, _ := MatchName(pattern, termData.Name); ok {
+			filtered = append(filtered, termData)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,91 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMatchName(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, name string
+		match         bool
+	}{
+		{"db/replica/*", "db/replica/1", true},
+		{"db/replica/*", "db/replica", false},
+		{"db/replica/*", "db/replica/1/conn", false},
+		{"db/*", "db/primary", true},
+		{"db/**", "db/replica/1", true},
+		{"db/**", "db", true},
+		{"**/conn", "db/replica/1/conn", true},
+		{"db/**/conn", "db/conn", true},
+		{"db/**/conn", "cache/conn", false},
+		{"db/replica-[12]", "db/replica-2", true},
+		{"db", "db/primary", false},
+	} {
+		match, err := MatchName(tc.pattern, tc.name)
+		if err != nil || match != tc.match {
+			t.Errorf("MatchName(%q, %q) = %v, %v, want %v", tc.pattern, tc.name, match, err, tc.match)
+		}
+	}
+
+	if _, err := MatchName("db/[", "db/primary"); err != path.ErrBadPattern {
+		t.Errorf("Expected %v for a malformed pattern, got %v", path.ErrBadPattern, err)
+	}
+}
+
+func TestRemoveMatching(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLeaderResign("db/leader", ResignFunc(func(ctx context.Context) error { return nil })))
+
+	var closed []string
+	for _, name := range []string{"db/primary", "db/replica/1", "db/replica/2", "cache"} {
+		name := name
+		term.Add(name, func(ctx context.Context) error {
+			closed = append(closed, name)
+			return nil
+		})
+	}
+
+	if n, err := term.RemoveMatching("db/replica/*"); n != 2 || err != nil {
+		t.Errorf("Expected the 2 replicas to be removed, got %d, %v", n, err)
+	}
+	if n, err := term.RemoveMatching("db/leader"); n != 0 || err != nil {
+		t.Errorf("Expected the closers configured through the options not to be removed, got %d, %v", n, err)
+	}
+
+	results, err := term.CloseMatching(context.Background(), "db/**")
+	if err != nil || len(results) != 1 || results[0].Name != "db/primary" || results[0].Status != SUCCESS {
+		t.Errorf("Expected the primary to be closed, got %+v, %v", results, err)
+	}
+	if registered := term.Status().Registered; registered != 1 {
+		t.Errorf("Expected 1 registered resource left, got %d", registered)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if want := []string{"db/primary", "cache"}; !reflect.DeepEqual(closed, want) {
+		t.Errorf("Expected %v to be closed, got %v", want, closed)
+	}
+	if _, err := term.RemoveMatching("*"); err != ErrShuttingDown {
+		t.Errorf("Expected ErrShuttingDown once terminated, got %v", err)
+	}
+}
+
+func TestResultFilter(t *testing.T) {
+	result := TerminationResult{Result: []TerminationResultData{
+		{Name: "db/primary"},
+		{Name: "db/replica/1"},
+		{Name: "cache"},
+	}}
+
+	filtered := result.Filter("db/replica/*")
+	if len(filtered) != 1 || filtered[0].Name != "db/replica/1" {
+		t.Errorf("Expected the replica only, got %+v", filtered)
+	}
+}
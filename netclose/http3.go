@@ -0,0 +1,49 @@
+package netclose
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// shutdowner is implemented by the HTTP/3 servers of quic-go v0.48 and later,
+// which send GOAWAY frames and wait for the requests in flight.
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// gracefulCloser is implemented by the HTTP/3 servers of earlier quic-go
+// versions.
+type gracefulCloser interface {
+	CloseGracefully(timeout time.Duration) error
+}
+
+// HTTP3Server returns a closer shutting down a quic-go HTTP/3 server, such
+// as an *http3.Server, with the semantics of the net/http adapter without
+// depending on quic-go: the server sends GOAWAY to its clients and drains
+// the requests in flight for drain at most, bounded by the context of the
+// closer, then closes the connections left with CONNECTION_CLOSE. Servers
+// supporting neither Shutdown nor CloseGracefully are closed right away.
+func HTTP3Server(srv io.Closer, drain time.Duration) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		drainCtx, cancel := context.WithTimeout(ctx, drain)
+		defer cancel()
+
+		switch s := srv.(type) {
+		case shutdowner:
+			if err := s.Shutdown(drainCtx); err != nil {
+				// Draining did not complete, close the remaining connections.
+				srv.Close()
+				return err
+			}
+			return nil
+		case gracefulCloser:
+			deadline, _ := drainCtx.Deadline()
+			return s.CloseGracefully(time.Until(deadline))
+		default:
+			return srv.Close()
+		}
+	}
+}
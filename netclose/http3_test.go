@@ -0,0 +1,80 @@
+package netclose
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeHTTP3Server struct {
+	closed   bool
+	shutdown func(ctx context.Context) error
+}
+
+func (s *fakeHTTP3Server) Close() error {
+	s.closed = true
+	return nil
+}
+
+type shutdownServer struct{ *fakeHTTP3Server }
+
+func (s shutdownServer) Shutdown(ctx context.Context) error {
+	return s.shutdown(ctx)
+}
+
+type gracefulServer struct {
+	*fakeHTTP3Server
+	timeout time.Duration
+}
+
+func (s *gracefulServer) CloseGracefully(timeout time.Duration) error {
+	s.timeout = timeout
+	return nil
+}
+
+func TestHTTP3ServerShutdown(t *testing.T) {
+	srv := shutdownServer{&fakeHTTP3Server{shutdown: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	if err := HTTP3Server(srv, 20*time.Millisecond)(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the drain to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the drain period to bound the shutdown, took %v", elapsed)
+	}
+	if !srv.closed {
+		t.Error("Expected the connections left to be closed")
+	}
+
+	srv = shutdownServer{&fakeHTTP3Server{shutdown: func(ctx context.Context) error { return nil }}}
+	if err := HTTP3Server(srv, time.Second)(ctx); err != nil || srv.closed {
+		t.Errorf("Expected a clean shutdown, got %v (closed: %v)", err, srv.closed)
+	}
+}
+
+func TestHTTP3ServerCloseGracefully(t *testing.T) {
+	srv := &gracefulServer{fakeHTTP3Server: &fakeHTTP3Server{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := HTTP3Server(srv, time.Minute)(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if srv.timeout <= 0 || srv.timeout > 100*time.Millisecond {
+		t.Errorf("Expected the drain period to be bounded by the closer context, got %v", srv.timeout)
+	}
+}
+
+func TestHTTP3ServerClose(t *testing.T) {
+	srv := &fakeHTTP3Server{}
+	if err := HTTP3Server(srv, time.Second)(context.Background()); err != nil || !srv.closed {
+		t.Errorf("Expected the server to be closed, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Package netclose adapts plain net.Listener and net.Conn values to
+// terminator closers, for raw-socket servers that do not go through
+// net/http. The closers set a deadline before closing, so that accept loops
+// and handlers blocked in I/O return with a timeout error, which they can
+// tell apart from a failure with net.Error, instead of an arbitrary error.
+// PacketServer brings a graceful shutdown to packet-based servers.
+package netclose
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// deadliner is implemented by the listeners supporting deadlines, such as
+// *net.TCPListener and *net.UnixListener.
+type deadliner interface {
+	SetDeadline(t time.Time) error
+}
+
+// writeCloser is implemented by the connections supporting half-closes,
+// such as *net.TCPConn and *net.UnixConn.
+type writeCloser interface {
+	CloseWrite() error
+}
+
+// Listener returns a closer stopping the accept loop of l: the pending
+// Accept calls return a timeout error, then the listener is closed. A
+// listener already closed is not an error.
+func Listener(l net.Listener) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		if d, ok := l.(deadliner); ok {
+			d.SetDeadline(time.Now())
+		}
+
+		return ignoreClosed(l.Close())
+	}
+}
+
+// Conn returns a closer closing c gracefully: the pending reads and writes
+// return a timeout error, the write side is shut down when supported so
+// that the peer receives an end of stream after the data already written,
+// then the connection is closed, even if the deadline cannot be set. A
+// connection already closed is not an error.
+func Conn(c net.Conn) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		deadlineErr := c.SetDeadline(time.Now())
+		if w, ok := c.(writeCloser); ok && deadlineErr == nil {
+			w.CloseWrite()
+		}
+
+		return errors.Join(ignoreClosed(deadlineErr), ignoreClosed(c.Close()))
+	}
+}
+
+// ignoreClosed returns nil for the errors reporting a closed network
+// connection.
+func ignoreClosed(err error) error {
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,129 @@
+package netclose
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := make(chan error, 1)
+	go func() {
+		_, err := l.Accept()
+		accepted <- err
+	}()
+
+	closer := Listener(l)
+	if err := closer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-accepted:
+		if err == nil {
+			t.Error("Expected Accept to fail")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept should return once the listener is closed")
+	}
+
+	if err := closer(context.Background()); err != nil {
+		t.Errorf("Closing a closed listener shouldn't fail, got %v", err)
+	}
+}
+
+func TestConn(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		peer, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer peer.Close()
+
+		data, _ := ioutil.ReadAll(peer)
+		received <- string(data)
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		readErr <- err
+	}()
+
+	if _, err := conn.Write([]byte("bye")); err != nil {
+		t.Fatal(err)
+	}
+
+	closer := Conn(conn)
+	if err := closer(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-readErr:
+		if err == nil {
+			t.Error("Expected the pending read to fail")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The pending read should return")
+	}
+
+	select {
+	case data := <-received:
+		if data != "bye" {
+			t.Errorf("Expected the peer to receive the data written before the close, got %q", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The peer should receive an end of stream")
+	}
+
+	if err := closer(context.Background()); err != nil {
+		t.Errorf("Closing a closed connection shouldn't fail, got %v", err)
+	}
+}
+
+// noDeadlineConn is a connection that doesn't support deadlines.
+type noDeadlineConn struct {
+	net.Conn
+	closed bool
+}
+
+var errNoDeadline = errors.New("deadlines not supported")
+
+func (c *noDeadlineConn) SetDeadline(t time.Time) error { return errNoDeadline }
+
+func (c *noDeadlineConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestConnDeadlineFailure(t *testing.T) {
+	conn := &noDeadlineConn{}
+
+	if err := Conn(conn)(context.Background()); !errors.Is(err, errNoDeadline) {
+		t.Errorf("Expected the deadline error, got %v", err)
+	}
+	if !conn.closed {
+		t.Error("Expected the connection to be closed even if the deadline cannot be set")
+	}
+}
@@ -0,0 +1,85 @@
+package netclose
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// maxPacketSize is the largest UDP payload.
+const maxPacketSize = 64 << 10
+
+// PacketServer serves a packet-based protocol, such as a UDP service, with
+// the graceful shutdown http.Server offers: Shutdown stops reading packets,
+// waits for the handlers in flight, then closes the connection.
+type PacketServer struct {
+	conn    net.PacketConn
+	handle  func(packet []byte, addr net.Addr)
+	tracker *terminator.Tracker
+
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPacketServer creates a server handling every packet read from conn
+// with handle, in its own goroutine. The handlers can reply with
+// conn.WriteTo until the server is shut down.
+func NewPacketServer(conn net.PacketConn, handle func(packet []byte, addr net.Addr)) *PacketServer {
+	return &PacketServer{
+		conn:     conn,
+		handle:   handle,
+		tracker:  terminator.NewTracker(),
+		stopping: make(chan struct{}),
+	}
+}
+
+// Serve reads and handles packets until the server is shut down, returning
+// nil, or reading fails.
+func (s *PacketServer) Serve() error {
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		select {
+		case <-s.stopping:
+			return nil
+		default:
+		}
+		if err != nil {
+			return err
+		}
+
+		if !s.tracker.Begin() {
+			return nil
+		}
+		packet := append([]byte(nil), buf[:n]...)
+		go func() {
+			defer s.tracker.Done()
+			s.handle(packet, addr)
+		}()
+	}
+}
+
+// Shutdown is the closer of the server: it stops reading packets, waits
+// until the handlers in flight returned or ctx is done, then closes the
+// connection. It returns the error of ctx if the handlers did not return in
+// time, the connection being closed nonetheless.
+func (s *PacketServer) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stopping) })
+	s.conn.SetReadDeadline(time.Now())
+
+	err := s.tracker.Drain(ctx)
+	if closeErr := ignoreClosed(s.conn.Close()); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Active returns the number of packets being handled.
+func (s *PacketServer) Active() int {
+	return s.tracker.Active()
+}
@@ -0,0 +1,101 @@
+package netclose
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPacketServer(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handling := make(chan struct{})
+	release := make(chan struct{})
+	srv := NewPacketServer(conn, func(packet []byte, addr net.Addr) {
+		close(handling)
+		<-release
+		conn.WriteTo(append([]byte("echo "), packet...), addr)
+	})
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve() }()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Write([]byte("ping"))
+	<-handling
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- srv.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Expected Serve to stop cleanly, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve should return once shutting down")
+	}
+
+	select {
+	case <-shutdown:
+		t.Fatal("Shutdown should wait for the handler")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if srv.Active() != 1 {
+		t.Errorf("Expected 1 packet being handled, got %d", srv.Active())
+	}
+
+	close(release)
+	if err := <-shutdown; err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	reply := make([]byte, 64)
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := client.Read(reply)
+	if err != nil || string(reply[:n]) != "echo ping" {
+		t.Errorf("Expected the handler to reply before the connection closed, got %q (%v)", reply[:n], err)
+	}
+}
+
+func TestPacketServerShutdownTimeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handling := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv := NewPacketServer(conn, func(packet []byte, addr net.Addr) {
+		close(handling)
+		<-release
+	})
+	go srv.Serve()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Write([]byte("ping"))
+	<-handling
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if _, err := conn.WriteTo([]byte("late"), client.LocalAddr()); err == nil {
+		t.Error("Expected the connection to be closed")
+	}
+}
@@ -0,0 +1,250 @@
+package terminator
+
+import "time"
+
+// registrationSettle is how long registrations must be quiet before a signal
+// received during startup proceeds to close the registered resources.
+const registrationSettle = 100 * time.Millisecond
+
+// Option configures the terminator created by NewTerminator.
+type Option func(*config)
+
+// config holds the settings applied through Option values.
+type config struct {
+	registrationGrace time.Duration
+
+	// exitBeforeReady makes a signal received before Ready exit the process
+	// with notReadyExitCode instead of closing the registered resources.
+	exitBeforeReady  bool
+	notReadyExitCode int
+
+	// callbackOnWaitTimeout delivers the partial result to the callback when Wait times out.
+	callbackOnWaitTimeout bool
+
+	logger Logger
+
+	// debug logs every step of the termination, see WithEnvConfig.
+	debug bool
+
+	// envConfig applies the overrides of the environment after the options.
+	envConfig bool
+
+	eventHandler func(Event)
+
+	// registrationGauge receives the number of registered resources on every change.
+	registrationGauge func(registered, peak int)
+
+	// resourceAccounting records the ResourceUsage of every closer.
+	resourceAccounting bool
+
+	panicPolicy PanicPolicy
+
+	// terminateOnError triggers the termination on the first error of a managed goroutine.
+	terminateOnError bool
+
+	// shutdownBudget bounds the time all closers have together.
+	shutdownBudget time.Duration
+
+	// pauseExcludedFromBudget extends the budget by the time spent paused.
+	pauseExcludedFromBudget bool
+
+	// exclusiveSignals resets the handlers of the close signals before subscribing to them.
+	exclusiveSignals bool
+
+	// parallelPhases closes the resources of a phase concurrently, within
+	// groupLimits and at most concurrency at a time if positive.
+	parallelPhases bool
+	concurrency    int
+	groupLimits    map[string]int
+
+	// environment gates the resources registered WithEnvironments.
+	environment string
+
+	// slo and phaseBudgets are the objectives reported in the SLOReport.
+	slo          time.Duration
+	phaseBudgets map[Phase]time.Duration
+
+	// watchdog exits the process with watchdogExitCode if the termination is stuck.
+	watchdog         time.Duration
+	watchdogExitCode int
+
+	// loopCaptureCheck is the number of closers sharing their captured
+	// variables that triggers a warning, see WithLoopCaptureCheck.
+	loopCaptureCheck int
+
+	// summaryFile receives the ExitSummary at the end of the termination.
+	summaryFile string
+
+	// healthFile holds healthyContent between Ready and the start of the
+	// termination, and unhealthyContent, or is removed, otherwise.
+	healthFile       string
+	healthyContent   string
+	unhealthyContent string
+
+	// gcSafetyNet watches the resources registered as Critical.
+	gcSafetyNet bool
+
+	// beforeEach adjusts the timeout of every closer just before it runs.
+	beforeEach BeforeEachFunc
+
+	// standardPhases warns about the resources registered in other phases.
+	standardPhases bool
+
+	triggerSources []TriggerSource
+	quiesceWindow  QuiesceWindowFunc
+
+	// announcers run in PhaseAnnounce, within announceTimeout each.
+	announcers      []announcer
+	announceTimeout time.Duration
+
+	// resigners give up leadership in PhaseAnnounce, before the announcers.
+	resigners []announcer
+
+	// sidecarQuitURLs are posted to once the result is reported.
+	sidecarQuitURLs []string
+
+	// closeLateRegistrations closes the resources registered during the termination.
+	closeLateRegistrations bool
+
+	// reporters report the result once the termination completed.
+	reporters []Reporter
+
+	// webhooks are notified when the termination starts and completes.
+	webhooks []webhook
+
+	// resultSummary aggregates the results, retaining the summarySlowest slowest resources.
+	resultSummary  bool
+	summarySlowest int
+
+	// shutdownJitter bounds the random delay before closing the resources.
+	shutdownJitter time.Duration
+
+	// hookTimeout bounds the time the callback and the BeforeEach hook have to return.
+	hookTimeout time.Duration
+
+	// requestGrace is how long request contexts outlive the start of the termination.
+	requestGrace time.Duration
+
+	// drainWindow and phaseDrainWindows bound the soft stage of the two-stage drain.
+	drainWindow       time.Duration
+	phaseDrainWindows map[Phase]time.Duration
+}
+
+// defaultConfig returns the configuration used when no options are given.
+func defaultConfig() config {
+	return config{
+		logger:          defaultLogger,
+		announceTimeout: defaultAnnounceTimeout,
+		requestGrace:    defaultDrainTimeout,
+	}
+}
+
+// WithRegistrationGrace sets how long the terminator waits for registrations
+// to settle when the termination signal arrives during startup, that is
+// before Ready was called. The wait ends early once Ready is called.
+// By default, as with a zero duration, the signal closes the resources
+// registered so far immediately.
+func WithRegistrationGrace(d time.Duration) Option {
+	return func(c *config) {
+		c.registrationGrace = d
+	}
+}
+
+// WithNotReadyExit makes the terminator exit the process immediately with
+// the given exit code when the termination signal arrives before Ready was
+// called, without closing the registered resources. By default such a signal
+// closes the resources registered so far, after waiting for registrations as
+// configured by WithRegistrationGrace.
+func WithNotReadyExit(code int) Option {
+	return func(c *config) {
+		c.exitBeforeReady = true
+		c.notReadyExitCode = code
+	}
+}
+
+// WithCallbackOnWaitTimeout makes Wait deliver the partial result to the
+// callback when it times out while resources are still being closed. The
+// callback is invoked at most once, so it is not invoked again with the final
+// result, which remains available through Result.
+func WithCallbackOnWaitTimeout() Option {
+	return func(c *config) {
+		c.callbackOnWaitTimeout = true
+	}
+}
+
+// WithLogger sets the logger used to report warnings, which are written to
+// the standard error by default.
+func WithLogger(logger Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithEventHandler sets a handler receiving the events of the termination
+// process. It is called synchronously from the goroutines closing the
+// resources, so it must be safe for concurrent use and return quickly.
+func WithEventHandler(handler func(Event)) Option {
+	return func(c *config) {
+		c.eventHandler = handler
+	}
+}
+
+// WithRegistrationGauge sets a hook receiving the current and peak number of
+// registered resources whenever a resource is registered or removed, to be
+// exported as gauges: a count growing steadily reveals per-request resources
+// registered but never removed. The hook is called synchronously from Add and
+// the Handle methods, one call at a time, so it must return quickly.
+func WithRegistrationGauge(gauge func(registered, peak int)) Option {
+	return func(c *config) {
+		c.registrationGauge = gauge
+	}
+}
+
+// WithResourceAccounting records a rough ResourceUsage of every closer in its
+// result data. Sampling the memory statistics briefly stops the world, so it
+// is disabled by default.
+func WithResourceAccounting() Option {
+	return func(c *config) {
+		c.resourceAccounting = true
+	}
+}
+
+// WithPanicPolicy sets how failures of the goroutines started with Go are
+// handled, PanicCrash by default.
+func WithPanicPolicy(policy PanicPolicy) Option {
+	return func(c *config) {
+		c.panicPolicy = policy
+	}
+}
+
+// WithTerminateOnError makes the first error returned by a goroutine started
+// with Go trigger the graceful termination, like an errgroup cancels its
+// context. The termination reports ReasonRoutineError and the error is
+// returned by TerminationResult.Err. Errors returned once the goroutine's
+// context is canceled are not considered.
+func WithTerminateOnError() Option {
+	return func(c *config) {
+		c.terminateOnError = true
+	}
+}
+
+// WithShutdownBudget bounds the time all the closers have together. The
+// context of every closer expires at the latest when the budget is spent,
+// except for closers registered with WithDetachedContext. There is no budget
+// by default.
+func WithShutdownBudget(d time.Duration) Option {
+	return func(c *config) {
+		c.shutdownBudget = d
+	}
+}
+
+// WithExclusiveSignals makes the terminator take exclusive ownership of its
+// close signals: signal.Reset is called for them before subscribing, so that
+// no other signal.Notify handler, such as one installed by a library, also
+// reacts to them. A warning is logged for every other handler declared with
+// RegisterSignalHandler, as they stop receiving the signals.
+func WithExclusiveSignals() Option {
+	return func(c *config) {
+		c.exclusiveSignals = true
+	}
+}
@@ -0,0 +1,140 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+)
+
+// WithParallelPhases closes the resources of a phase concurrently instead
+// of one at a time. Phases are still closed one after another, and the
+// results are still reported in execution order. The number of resources
+// of a group closed concurrently can be limited with WithGroupLimit.
+func WithParallelPhases() Option {
+	return func(c *config) {
+		c.parallelPhases = true
+	}
+}
+
+// WithConcurrency closes up to n resources of a phase concurrently, for
+// stacks of many independent resources closed needlessly slowly one at a
+// time. Phases are still closed one after another, and the results are still
+// complete and reported in execution order, as with WithParallelPhases.
+// With n of 1 or less, the resources are closed one at a time.
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.parallelPhases = n > 1
+		c.concurrency = 0
+		if n > 1 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithGroupLimit limits to n the resources of the group closed concurrently
+// when phases run in parallel, for instance to avoid spiking the connection
+// churn on shared infrastructure. The resources of the group are started in
+// execution order as slots free up. Groups without a limit are unbounded.
+func WithGroupLimit(group string, n int) Option {
+	return func(c *config) {
+		if c.groupLimits == nil {
+			c.groupLimits = make(map[string]int)
+		}
+		c.groupLimits[group] = n
+	}
+}
+
+// InGroup registers the resource in the given concurrency group, see WithGroupLimit.
+func InGroup(group string) CloserOption {
+	return func(p *payload) {
+		p.Group = group
+	}
+}
+
+// groupLimit returns the number of resources of the closer's group closed
+// concurrently, zero if unbounded.
+func (c *config) groupLimit(closer *payload) int {
+	if closer.Group == "" {
+		return 0
+	}
+
+	return c.groupLimits[closer.Group]
+}
+
+// closePhase closes the resources of a single phase concurrently, within the
+// limits of their groups. Every result is appended once all the resources
+// before it in execution order are closed.
+func (t *terminator) closePhase(ctx context.Context, closers []payload, result *TerminationResult) {
+	data := make([]TerminationResultData, len(closers))
+	finished := make([]bool, len(closers))
+	flushed := 0
+
+	// slots bounds the resources closed concurrently across groups, if limited.
+	var slots chan struct{}
+	if t.config.concurrency > 0 {
+		slots = make(chan struct{}, t.config.concurrency)
+	}
+	acquire := func() {
+		if slots != nil {
+			slots <- struct{}{}
+		}
+	}
+
+	closeOne := func(index int) {
+		termData := t.closeOne(ctx, &closers[index])
+		if slots != nil {
+			<-slots
+		}
+
+		t.mu.Lock()
+		data[index] = termData
+		finished[index] = true
+		for ; flushed < len(closers) && finished[flushed]; flushed++ {
+			t.record(result, result.closed, data[flushed])
+		}
+		t.mu.Unlock()
+
+		t.emit(Event{Kind: EventCloserFinished, Name: termData.Name, CloserProgress: 1, Data: &termData})
+	}
+
+	var wg sync.WaitGroup
+	queues := make(map[string]chan int)
+
+	for index := range closers {
+		limit := t.config.groupLimit(&closers[index])
+		if limit <= 0 {
+			acquire()
+			wg.Add(1)
+			go func(index int) {
+				defer wg.Done()
+				closeOne(index)
+			}(index)
+			continue
+		}
+
+		group := closers[index].Group
+		queue, ok := queues[group]
+		if !ok {
+			queue = make(chan int, len(closers))
+			queues[group] = queue
+
+			for worker := 0; worker < limit; worker++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for index := range queue {
+						acquire()
+						closeOne(index)
+					}
+				}()
+			}
+		}
+
+		queue <- index
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+}
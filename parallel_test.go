@@ -0,0 +1,190 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParallelPhases(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithParallelPhases())
+
+	var mu sync.Mutex
+	var order []string
+	closer := func(name string, delay time.Duration) CloseFunc {
+		return func(ctx context.Context) error {
+			time.Sleep(delay)
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	term.Add("db", closer("db", 10*time.Millisecond))
+	term.AddWithOptions("server1", closer("server1", 100*time.Millisecond), InPhase(PhaseServer))
+	term.AddWithOptions("server2", closer("server2", 50*time.Millisecond), InPhase(PhaseServer))
+	term.AddWithOptions("server3", closer("server3", 100*time.Millisecond), InPhase(PhaseServer))
+
+	start := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("Expected the server phase to close concurrently, took %v", elapsed)
+	}
+
+	if len(order) != 4 || order[0] != "server2" || order[3] != "db" {
+		t.Errorf("Expected the phases to close one after another, got %v", order)
+	}
+
+	result, _ := term.Result()
+	names := make([]string, 0, len(result.Result))
+	for _, termData := range result.Result {
+		names = append(names, termData.Name)
+	}
+	expected := []string{"server3", "server2", "server1", "db"}
+	for i := range expected {
+		if i >= len(names) || names[i] != expected[i] {
+			t.Fatalf("Expected results in execution order %v, got %v", expected, names)
+		}
+	}
+}
+
+func TestWithConcurrency(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithConcurrency(3))
+
+	// The closers hold on until two of them run at once, so that the
+	// concurrency is observed however the goroutines are scheduled.
+	concurrent := make(chan struct{})
+	var mu sync.Mutex
+	running, peak := 0, 0
+	for i := 0; i < 8; i++ {
+		delay := time.Duration(i%3) * 10 * time.Millisecond
+		term.Add("worker-"+strconv.Itoa(i), func(ctx context.Context) error {
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+				if peak == 2 {
+					close(concurrent)
+				}
+			}
+			mu.Unlock()
+
+			select {
+			case <-concurrent:
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			time.Sleep(delay)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(5 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if peak > 3 || peak < 2 {
+		t.Errorf("Expected 2 or 3 resources closed concurrently at most, got %d", peak)
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 8 {
+		t.Fatalf("Expected the results of the 8 resources, got %d", len(result.Result))
+	}
+	for index, termData := range result.Result {
+		if expected := "worker-" + strconv.Itoa(7-index); termData.Name != expected {
+			t.Errorf("Expected %s at position %d, got %s", expected, index, termData.Name)
+		}
+	}
+
+	if config := NewTerminator(nil, WithConcurrency(3), WithConcurrency(1)).(*terminator).config; config.parallelPhases {
+		t.Error("Expected a concurrency of 1 to close the resources one at a time")
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithParallelPhases(), WithGroupLimit("tenant-db", 2))
+
+	var mu sync.Mutex
+	var active, peak int
+	for i := 0; i < 6; i++ {
+		term.AddWithOptions("tenant"+strconv.Itoa(i), func(ctx context.Context) error {
+			mu.Lock()
+			active++
+			if active > peak {
+				peak = active
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+			return nil
+		}, InGroup("tenant-db"))
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if peak != 2 {
+		t.Errorf("Expected at most 2 tenant connections closed concurrently, got %d", peak)
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 6 || result.Result[0].Name != "tenant5" {
+		t.Errorf("Expected all the tenants in execution order, got %+v", result.Result)
+	}
+}
+
+func TestDryRunParallelPhases(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithParallelPhases(), WithGroupLimit("tenant-db", 2))
+	noop := func(ctx context.Context) error { return nil }
+
+	term.AddWithOptions("tenant1", noop, InGroup("tenant-db"), WithCloserTimeout(time.Second))
+	term.AddWithOptions("tenant2", noop, InGroup("tenant-db"), WithCloserTimeout(2*time.Second))
+	term.AddWithOptions("tenant3", noop, InGroup("tenant-db"), WithCloserTimeout(3*time.Second))
+	term.AddWithOptions("cache", noop, WithCloserTimeout(time.Second))
+	term.AddWithOptions("telemetry", noop, InPhase(PhaseTelemetry), WithCloserTimeout(time.Second))
+
+	report := term.DryRun(Simulation{})
+
+	expected := []DryRunEntry{
+		{Name: "cache", Start: 0, End: time.Second},
+		{Name: "tenant3", Start: 0, End: 3 * time.Second},
+		{Name: "tenant2", Start: 0, End: 2 * time.Second},
+		{Name: "tenant1", Start: 2 * time.Second, End: 3 * time.Second},
+		{Name: "telemetry", Phase: PhaseTelemetry, Start: 3 * time.Second, End: 4 * time.Second},
+	}
+
+	if len(report.Entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), report.Entries)
+	}
+	for i, entry := range report.Entries {
+		if entry != expected[i] {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, expected[i], entry)
+		}
+	}
+
+	if report.Total != 4*time.Second {
+		t.Errorf("Expected a total of 4s, got %v", report.Total)
+	}
+}
@@ -0,0 +1,103 @@
+package terminator
+
+import (
+	"context"
+	"time"
+)
+
+// Events emitted by Pause and Resume.
+const (
+	// EventPaused is emitted when the termination is paused.
+	EventPaused EventKind = "PAUSED"
+
+	// EventResumed is emitted when the termination is resumed.
+	EventResumed EventKind = "RESUMED"
+)
+
+// WithPauseExcludedFromBudget excludes the time the termination spends
+// paused from the shutdown budget, see WithShutdownBudget, so that a
+// debugging session does not make the closers left time out. Closers already
+// running when the termination was paused keep their deadline.
+func WithPauseExcludedFromBudget() Option {
+	return func(c *config) {
+		c.pauseExcludedFromBudget = true
+	}
+}
+
+// Pause halts the termination between two closers, for an engineer to
+// inspect the state of the process in a live debugging session, typically
+// from an admin endpoint. The closers running keep running, and no other
+// closer starts until Resume is called or the termination is aborted. Pausing
+// before the termination started halts it before its first closer. The
+// watchdog is not paused. It reports false if the termination is already
+// paused or completed.
+func (t *terminator) Pause() bool {
+	t.mu.Lock()
+	if t.pauseChan != nil || t.done {
+		t.mu.Unlock()
+		return false
+	}
+	t.pauseChan = make(chan struct{})
+	t.pausedAt = time.Now()
+	t.mu.Unlock()
+
+	t.config.logger.Printf("termination paused: call Resume to continue")
+	t.emit(Event{Kind: EventPaused})
+
+	return true
+}
+
+// Resume resumes the termination paused by Pause. It reports false if the
+// termination is not paused.
+func (t *terminator) Resume() bool {
+	t.mu.Lock()
+	if t.pauseChan == nil {
+		t.mu.Unlock()
+		return false
+	}
+	close(t.pauseChan)
+	t.pauseChan = nil
+
+	paused := time.Since(t.pausedAt)
+	if t.config.pauseExcludedFromBudget && !t.budgetDeadline.IsZero() {
+		t.budgetDeadline = t.budgetDeadline.Add(paused)
+	}
+	t.mu.Unlock()
+
+	t.config.logger.Printf("termination resumed after %v", paused)
+	t.emit(Event{Kind: EventResumed})
+
+	return true
+}
+
+// awaitResume blocks while the termination is paused, until Resume or Abort
+// is called.
+func (t *terminator) awaitResume(name string) {
+	t.mu.Lock()
+	pauseChan := t.pauseChan
+	t.mu.Unlock()
+
+	if pauseChan == nil {
+		return
+	}
+
+	t.config.logger.Printf("termination paused before closing %q", name)
+	select {
+	case <-pauseChan:
+	case <-t.abortChan:
+	}
+}
+
+// budgetContext returns a context derived from ctx expiring at the end of
+// the shutdown budget, if any.
+func (t *terminator) budgetContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	t.mu.Lock()
+	deadline := t.budgetDeadline
+	t.mu.Unlock()
+
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, deadline)
+}
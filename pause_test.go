@@ -0,0 +1,104 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPauseResume(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(make(lineLogger, 8)))
+
+	closed := make(chan string, 2)
+	term.Add("second", func(ctx context.Context) error {
+		closed <- "second"
+		return nil
+	})
+	term.Add("first", func(ctx context.Context) error {
+		closed <- "first"
+		term.Pause()
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if name := <-closed; name != "first" {
+		t.Fatalf("Expected the first resource to be closed, got %q", name)
+	}
+
+	select {
+	case name := <-closed:
+		t.Fatalf("Expected the termination to be paused, got %q closed", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if !term.Status().Paused {
+		t.Error("Expected the status to report the pause")
+	}
+	if term.Pause() {
+		t.Error("Expected Pause to report false while paused")
+	}
+
+	if !term.Resume() {
+		t.Fatal("Expected Resume to resume the termination")
+	}
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+	if name := <-closed; name != "second" {
+		t.Errorf("Expected the second resource to be closed once resumed, got %q", name)
+	}
+	if term.Resume() {
+		t.Error("Expected Resume to report false when not paused")
+	}
+}
+
+func TestPauseExcludedFromBudget(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt},
+		WithRegistrationGrace(0),
+		WithLogger(make(lineLogger, 8)),
+		WithShutdownBudget(100*time.Millisecond),
+		WithPauseExcludedFromBudget())
+
+	term.Add("second", func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	term.Add("first", func(ctx context.Context) error {
+		term.Pause()
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			term.Resume()
+		}()
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if result.FailedOrTimeoutCount != 0 {
+		t.Errorf("Expected the pause not to consume the budget, got %+v", result.Result)
+	}
+}
+
+func TestPauseAbort(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(make(lineLogger, 8)))
+
+	term.Add("resource", func(ctx context.Context) error { return nil })
+	term.Pause()
+
+	term.(*terminator).signalChan <- os.Interrupt
+	for term.State() != StateClosing {
+		time.Sleep(time.Millisecond)
+	}
+	term.Abort()
+
+	if !term.Wait(time.Second) {
+		t.Fatal("Expected Abort to end the pause")
+	}
+	result, _ := term.Result()
+	if len(result.Result) != 1 || result.Result[0].Status != SKIPPED {
+		t.Errorf("Expected the resource to be skipped, got %+v", result.Result)
+	}
+}
@@ -0,0 +1,64 @@
+package terminator
+
+import (
+	"sort"
+	"time"
+)
+
+// Phase groups resources that are closed together. Phases are closed in
+// ascending order, and the resources within a phase in reverse order of
+// registration.
+type Phase int
+
+const (
+
+	// DefaultPhase is the phase of the resources registered without one.
+	DefaultPhase Phase = 0
+
+	// PhaseBackground stops background tasks, such as tickers, before anything else is closed.
+	PhaseBackground Phase = -600
+
+	// PhaseFinalizer runs finalizers, last. Resources in this phase or a later
+	// one are closed even when the termination is aborted, and Abort does not
+	// cancel their contexts.
+	PhaseFinalizer Phase = 2000
+)
+
+// CloserOption configures a resource registered with AddWithOptions.
+type CloserOption func(*payload)
+
+// InPhase registers the resource in the given phase.
+func InPhase(phase Phase) CloserOption {
+	return func(p *payload) {
+		p.Phase = phase
+	}
+}
+
+// WithCloserTimeout sets the timeout for closing the resource.
+func WithCloserTimeout(timeout time.Duration) CloserOption {
+	return func(p *payload) {
+		p.Timeout = timeout
+	}
+}
+
+// isFinalizer reports whether the resource is closed even when the termination is aborted.
+func (p *payload) isFinalizer() bool {
+	return p.Phase >= PhaseFinalizer
+}
+
+// executionOrder returns the closers of the stack in the order they are
+// closed, leaving out the removed entries.
+func executionOrder(stack []payload) []payload {
+	closers := make([]payload, 0, len(stack))
+	for stackIndex := len(stack) - 1; stackIndex >= 0; stackIndex-- {
+		if !stack[stackIndex].isRemoved() {
+			closers = append(closers, stack[stackIndex])
+		}
+	}
+
+	sort.SliceStable(closers, func(i, j int) bool {
+		return closers[i].Phase < closers[j].Phase
+	})
+
+	return closers
+}
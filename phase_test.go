@@ -0,0 +1,101 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPhaseOrder(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	result := []string{}
+	closer := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			result = append(result, name)
+			return nil
+		}
+	}
+
+	term.AddWithOptions("storage", closer("storage"), InPhase(10))
+	term.Add("app1", closer("app1"))
+	term.AddWithOptions("ingress", closer("ingress"), InPhase(-10))
+	term.Add("app2", closer("app2"))
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	expected := []string{"ingress", "app2", "app1", "storage"}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+		return
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, result)
+			return
+		}
+	}
+}
+
+func TestAddWithPriority(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var order []string
+	closer := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	term.AddWithPriority("listener", closer("listener"), 300)
+	term.AddWithPriority("workers", closer("workers"), 200)
+	term.Add("cache", closer("cache"))
+	term.AddWithPriority("db", closer("db"), -500)
+	term.AddWithOptions("server", closer("server"), InPhase(PhaseIngress))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	expected := []string{"server", "listener", "workers", "cache", "db"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for index := range expected {
+		if order[index] != expected[index] {
+			t.Fatalf("Expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestAddWithPriorityBounds(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	for priority, expected := range map[int]Phase{
+		699:   -699,
+		700:   -699,
+		10000: -699,
+		-1999: 1999,
+		-2000: 1999,
+		-5000: 1999,
+	} {
+		if _, err := term.AddWithPriority("resource", func(ctx context.Context) error { return nil }, priority); err != nil {
+			t.Fatal(err)
+		}
+
+		stack := term.(*terminator).closersStack
+		if phase := stack[len(stack)-1].Phase; phase != expected {
+			t.Errorf("Expected the priority %d in %v, got %v", priority, expected, phase)
+		}
+	}
+}
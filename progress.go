@@ -0,0 +1,206 @@
+package terminator
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind identifies the kind of an Event.
+type EventKind string
+
+const (
+
+	// EventShutdownStarted is emitted when the resources start being closed.
+	EventShutdownStarted EventKind = "SHUTDOWN_STARTED"
+
+	// EventCloserStarted is emitted when a resource starts being closed.
+	EventCloserStarted EventKind = "CLOSER_STARTED"
+
+	// EventCloserProgress is emitted when a closer reports its progress with SetProgress.
+	EventCloserProgress EventKind = "CLOSER_PROGRESS"
+
+	// EventCloserFinished is emitted when a resource is closed, successfully or not.
+	EventCloserFinished EventKind = "CLOSER_FINISHED"
+
+	// EventShutdownCompleted is emitted when all the resources are closed.
+	EventShutdownCompleted EventKind = "SHUTDOWN_COMPLETED"
+
+	// EventStateChanged is emitted when the lifecycle moves to another State.
+	EventStateChanged EventKind = "STATE_CHANGED"
+)
+
+// Event describes a step of the termination process.
+type Event struct {
+
+	// Kind of the event
+	Kind EventKind
+
+	// Time the event occurred
+	Time time.Time
+
+	// Name of the resource, for closer events
+	Name string
+
+	// Progress reported by the resource's closer, for closer events
+	CloserProgress float64
+
+	// Overall progress of the termination process, from 0 to 1
+	Progress float64
+
+	// Result data of the resource, for EventCloserFinished
+	Data *TerminationResultData
+
+	// Previous and new state, for EventStateChanged
+	From, To State
+}
+
+// Status is a snapshot of the termination process.
+type Status struct {
+
+	// Current state of the lifecycle
+	State State
+
+	// ShuttingDown is set once the termination signal is received
+	ShuttingDown bool
+
+	// Done is set once all the resources are closed, or skipped by Abort, and the callback returned
+	Done bool
+
+	// Number of resources being closed
+	Total int
+
+	// Number of resources already closed, successfully or not
+	Closed int
+
+	// Names of the resources currently being closed
+	Running []string
+
+	// Paused is set while the termination is paused, see Pause
+	Paused bool
+
+	// Current and highest number of registered resources, a steadily
+	// increasing count hinting at resources registered but never removed
+	Registered, PeakRegistered int
+
+	// Overall progress of the termination process, from 0 to 1.
+	// Running closers contribute the progress they report with SetProgress.
+	Progress float64
+}
+
+// SetProgress reports the progress of the closer running with ctx, as a
+// fraction from 0 to 1. It is meant to be called from within a CloseFunc and
+// does nothing when ctx does not belong to a closer.
+func SetProgress(ctx context.Context, progress float64) {
+	state := closerStateFrom(ctx)
+	if state == nil {
+		return
+	}
+
+	progress = math.Max(0, math.Min(1, progress))
+	atomic.StoreUint64(&state.progress, math.Float64bits(progress))
+
+	if !state.term.isRunning(state) {
+		return
+	}
+
+	state.term.emit(Event{
+		Kind:           EventCloserProgress,
+		Name:           state.name,
+		CloserProgress: progress,
+	})
+}
+
+// isRunning reports whether the state belongs to a closer run by the termination.
+func (t *terminator) isRunning(state *closerState) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.running[state]
+	return ok
+}
+
+// loadProgress returns the progress reported by the closer.
+func (state *closerState) loadProgress() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&state.progress))
+}
+
+// Status returns a snapshot of the termination process.
+func (t *terminator) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status()
+}
+
+// status returns a snapshot of the termination process. It must be called with mu held.
+func (t *terminator) status() Status {
+	status := Status{
+		State:          t.state,
+		ShuttingDown:   t.state != StateIdle,
+		Done:           t.state.IsTerminal(),
+		Total:          len(t.closing),
+		Registered:     t.registeredLocked(),
+		PeakRegistered: t.peakRegistered,
+		Paused:         t.pauseChan != nil,
+	}
+
+	if t.result != nil {
+		status.Closed = t.result.closed
+	}
+
+	progress := float64(status.Closed)
+	for state := range t.running {
+		status.Running = append(status.Running, state.name)
+		progress += state.loadProgress()
+	}
+
+	switch {
+	case status.Done || t.state == StateFinalizing:
+		status.Progress = 1
+	case status.Total > 0:
+		status.Progress = progress / float64(status.Total)
+	}
+
+	return status
+}
+
+// registeredLocked returns the number of registered resources, leaving out
+// the closers configured through the options. It must be called with mu held.
+func (t *terminator) registeredLocked() int {
+	return len(t.positions) - t.hooks
+}
+
+// emitRegistrations reports the number of registered resources to the
+// registration gauge, if any.
+func (t *terminator) emitRegistrations() {
+	if t.config.registrationGauge == nil {
+		return
+	}
+
+	// Reading the counts under gaugeMu delivers them in order.
+	t.gaugeMu.Lock()
+	defer t.gaugeMu.Unlock()
+
+	t.mu.Lock()
+	registered, peak := t.registeredLocked(), t.peakRegistered
+	t.mu.Unlock()
+
+	t.config.registrationGauge(registered, peak)
+}
+
+// emit delivers the event to the event handler, if any.
+func (t *terminator) emit(event Event) {
+	if t.config.debug {
+		t.logEvent(event)
+	}
+	if t.config.eventHandler == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	event.Progress = t.Status().Progress
+
+	t.config.eventHandler(event)
+}
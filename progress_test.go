@@ -0,0 +1,125 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetProgress(t *testing.T) {
+	var mu sync.Mutex
+	events := []Event{}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithEventHandler(func(e Event) {
+		if e.Kind == EventStateChanged {
+			return
+		}
+
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}))
+
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	term.Add("cache", func(ctx context.Context) error {
+		SetProgress(ctx, 0.5)
+		close(reported)
+		<-release
+		return nil
+	})
+	term.Add("server", func(ctx context.Context) error {
+		return nil
+	})
+
+	if status := term.Status(); status.ShuttingDown || status.Progress != 0 {
+		t.Errorf("Unexpected status before termination: %+v", status)
+	}
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	<-reported
+	status := term.Status()
+	if !status.ShuttingDown || status.Closed != 1 || status.Progress != 0.75 {
+		t.Errorf("Expected 75%% progress, got %+v", status)
+	}
+
+	if len(status.Running) != 1 || status.Running[0] != "cache" {
+		t.Errorf("cache should be running, got %v", status.Running)
+	}
+
+	close(release)
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if status := term.Status(); !status.Done || status.Progress != 1 {
+		t.Errorf("Unexpected status after termination: %+v", status)
+	}
+
+	expected := []EventKind{
+		EventShutdownStarted,
+		EventCloserStarted, EventCloserFinished,
+		EventCloserStarted, EventCloserProgress, EventCloserFinished,
+		EventShutdownCompleted,
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != len(expected) {
+		t.Errorf("Expected %d events, got %+v", len(expected), events)
+		return
+	}
+
+	for i, kind := range expected {
+		if events[i].Kind != kind {
+			t.Errorf("Event %d: expected %s, got %s", i, kind, events[i].Kind)
+		}
+	}
+
+	if events[4].CloserProgress != 0.5 || events[4].Progress != 0.75 {
+		t.Errorf("Unexpected progress event: %+v", events[4])
+	}
+}
+
+func TestSetProgressOutsideCloser(t *testing.T) {
+	// Must not panic.
+	SetProgress(context.Background(), 0.5)
+}
+
+func TestRegistrationGauge(t *testing.T) {
+	type sample struct{ registered, peak int }
+	var samples []sample
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithAnnouncer("registry", func(ctx context.Context) error { return nil }),
+		WithRegistrationGauge(func(registered, peak int) {
+			samples = append(samples, sample{registered, peak})
+		}))
+
+	noop := func(ctx context.Context) error { return nil }
+	first, _ := term.Add("first", noop)
+	second, _ := term.Add("second", noop)
+	first.Remove()
+	second.Close()
+	term.Add("third", noop)
+
+	expected := []sample{{1, 1}, {2, 2}, {1, 2}, {0, 2}, {1, 2}}
+	if len(samples) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, samples)
+	}
+	for i := range expected {
+		if samples[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, samples)
+			break
+		}
+	}
+
+	status := term.Status()
+	if status.Registered != 1 || status.PeakRegistered != 2 {
+		t.Errorf("Expected 1 registered resource and a peak of 2, got %d and %d", status.Registered, status.PeakRegistered)
+	}
+}
@@ -0,0 +1,43 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+)
+
+// Provide constructs a resource with construct and registers the closer it
+// returns under name, as configured by the options, so that the teardown of
+// the resource cannot be forgotten:
+//
+//	db, err := terminator.Provide(term, "db", func() (*sql.DB, terminator.CloseFunc, error) {
+//		db, err := sql.Open("postgres", dsn)
+//		if err != nil {
+//			return nil, nil, err
+//		}
+//		return db, func(ctx context.Context) error { return db.Close() }, nil
+//	})
+//
+// Nothing is registered when construct fails. If the registration is
+// rejected, with ErrSealed, the resource is closed right away and the error
+// of the registration is returned with the zero value, so that it does not
+// leak. It is closed only once with WithCloseLateRegistrations.
+func Provide[T any](term Terminator, name string, construct func() (T, CloseFunc, error), opts ...CloserOption) (T, error) {
+	var zero T
+
+	resource, close, err := construct()
+	if err != nil {
+		return zero, err
+	}
+	if close == nil {
+		return resource, nil
+	}
+
+	if _, err := term.AddWithOptions(name, close, opts...); err != nil {
+		if !errors.Is(err, ErrClosedOnRegistration) {
+			close(context.Background())
+		}
+		return zero, err
+	}
+
+	return resource, nil
+}
@@ -0,0 +1,86 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+type thing struct {
+	closed bool
+}
+
+func TestProvide(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var last *thing
+	newThing := func() (*thing, CloseFunc, error) {
+		th := &thing{}
+		last = th
+		return th, func(ctx context.Context) error {
+			th.closed = true
+			return nil
+		}, nil
+	}
+
+	th, err := Provide(term, "thing", newThing)
+	if err != nil || th == nil {
+		t.Fatalf("Expected the resource, got %v, %v", th, err)
+	}
+
+	errConstruct := errors.New("construct failed")
+	if _, err := Provide(term, "broken", func() (*thing, CloseFunc, error) {
+		return nil, nil, errConstruct
+	}); err != errConstruct {
+		t.Errorf("Expected the error of the constructor, got %v", err)
+	}
+	if registered := term.Status().Registered; registered != 1 {
+		t.Errorf("Expected 1 registered resource, got %d", registered)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+	if !th.closed {
+		t.Error("Expected the provided resource to be closed")
+	}
+
+	late, err := Provide(term, "late", newThing)
+	if err != ErrSealed || late != nil {
+		t.Errorf("Expected ErrSealed once the termination started, got %v, %v", late, err)
+	}
+	if !last.closed {
+		t.Error("Expected the resource rejected to be closed right away")
+	}
+}
+
+func TestProvideClosedOnRegistration(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithCloseLateRegistrations())
+
+	closes := 0
+	var lateErr error
+	term.Add("db", func(ctx context.Context) error {
+		_, lateErr = Provide(term, "late", func() (*thing, CloseFunc, error) {
+			return &thing{}, func(ctx context.Context) error {
+				closes++
+				return nil
+			}, nil
+		})
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if lateErr != ErrClosedOnRegistration {
+		t.Errorf("Expected ErrClosedOnRegistration, got %v", lateErr)
+	}
+	if closes != 1 {
+		t.Errorf("Expected the resource to be closed once, got %d closes", closes)
+	}
+}
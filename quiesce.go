@@ -0,0 +1,57 @@
+package terminator
+
+import "time"
+
+// QuiesceWindowFunc returns the start of the next low-traffic window after
+// now. Returning now, or a time before it, means the window is open.
+type QuiesceWindowFunc func(now time.Time) time.Time
+
+// WithQuiesceWindow defers the non-urgent terminations, the ones started by
+// a TriggerSource or with TerminateWhenQuiet, to the next low-traffic window returned by
+// next. The terminator stays idle meanwhile, and a signal or any other
+// trigger received before the window opens starts the termination right away.
+func WithQuiesceWindow(next QuiesceWindowFunc) Option {
+	return func(c *config) {
+		c.quiesceWindow = next
+	}
+}
+
+// quiesceTimer holds a deferred trigger until the quiesce window opens.
+type quiesceTimer struct {
+	trig  trigger
+	timer *time.Timer
+}
+
+// deferUntilQuiet returns a timer holding the trigger until the quiesce
+// window opens, or nil if the termination can start right away.
+func (t *terminator) deferUntilQuiet(trig trigger) *quiesceTimer {
+	if t.config.quiesceWindow == nil {
+		return nil
+	}
+
+	now := time.Now()
+	start := t.config.quiesceWindow(now)
+	if !start.After(now) {
+		return nil
+	}
+
+	t.config.logger.Printf("termination (%s) deferred to the quiesce window at %s", trig.reason, start.Format(time.RFC3339))
+
+	return &quiesceTimer{trig: trig, timer: time.NewTimer(start.Sub(now))}
+}
+
+// C returns the channel receiving once the window opens, nil for a nil timer.
+func (q *quiesceTimer) C() <-chan time.Time {
+	if q == nil {
+		return nil
+	}
+
+	return q.timer.C
+}
+
+// Stop releases the timer, if any.
+func (q *quiesceTimer) Stop() {
+	if q != nil {
+		q.timer.Stop()
+	}
+}
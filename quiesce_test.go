@@ -0,0 +1,121 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+type firingSource struct{}
+
+func (firingSource) Wait(ctx context.Context) error { return nil }
+
+func TestQuiesceWindowDefersTrigger(t *testing.T) {
+	window := time.Now().Add(200 * time.Millisecond)
+	next := func(now time.Time) time.Time { return window }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithQuiesceWindow(next), WithTriggerSource(firingSource{}))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	time.Sleep(50 * time.Millisecond)
+	if term.IsShuttingDown() {
+		t.Fatal("The termination should wait for the quiesce window")
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The termination should start once the window opens")
+	}
+	if time.Now().Before(window) {
+		t.Error("The termination completed before the window opened")
+	}
+
+	result, _ := term.Result()
+	if result.Reason != ReasonTriggerSource {
+		t.Errorf("Expected reason %q, got %q", ReasonTriggerSource, result.Reason)
+	}
+}
+
+func TestQuiesceWindowSignalRunsImmediately(t *testing.T) {
+	next := func(now time.Time) time.Time { return now.Add(time.Hour) }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithQuiesceWindow(next), WithTriggerSource(firingSource{}))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	time.Sleep(50 * time.Millisecond)
+	term.(*terminator).signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("A signal should start the termination without waiting for the window")
+	}
+
+	result, _ := term.Result()
+	if result.Reason != ReasonSignal {
+		t.Errorf("Expected reason %q, got %q", ReasonSignal, result.Reason)
+	}
+}
+
+func TestQuiesceWindowOpen(t *testing.T) {
+	next := func(now time.Time) time.Time { return now }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithQuiesceWindow(next), WithTriggerSource(firingSource{}))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The termination should start right away within the window")
+	}
+}
+
+func TestTerminateWhenQuiet(t *testing.T) {
+	window := time.Now().Add(200 * time.Millisecond)
+	next := func(now time.Time) time.Time { return window }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithQuiesceWindow(next))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if !term.TerminateWhenQuiet("planned restart") {
+		t.Fatal("Expected the termination to be triggered")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if term.IsShuttingDown() {
+		t.Fatal("The termination should wait for the quiesce window")
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The termination should start once the window opens")
+	}
+	if time.Now().Before(window) {
+		t.Error("The termination completed before the window opened")
+	}
+
+	result, _ := term.Result()
+	if result.Reason != "planned restart" || !result.Programmatic {
+		t.Errorf("Expected a programmatic termination with its reason, got %q %v", result.Reason, result.Programmatic)
+	}
+}
+
+func TestTerminateDuringQuietDeferral(t *testing.T) {
+	next := func(now time.Time) time.Time { return now.Add(time.Hour) }
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithQuiesceWindow(next))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.TerminateWhenQuiet("planned restart")
+	time.Sleep(20 * time.Millisecond)
+	term.Terminate("replica diverged")
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Terminate should start the termination without waiting for the window")
+	}
+
+	result, _ := term.Result()
+	if result.Reason != "replica diverged" {
+		t.Errorf("Expected reason %q, got %q", "replica diverged", result.Reason)
+	}
+}
@@ -0,0 +1,50 @@
+package terminator
+
+import "context"
+
+// ReasonRehearsal is the reason reported by the results of Rehearse.
+const ReasonRehearsal = "rehearsal"
+
+// WithRehearsal sets the function tearing down a staging copy of the
+// resource during a rehearsal, see Terminator.Rehearse. It should close a
+// freshly created copy of the resource, never the one in use. Resources
+// registered without one are skipped by rehearsals.
+func WithRehearsal(rehearse CloseFunc) CloserOption {
+	return func(p *payload) {
+		p.Rehearse = rehearse
+	}
+}
+
+// Rehearse runs the rehearsal functions of the registered resources in the
+// order of the termination, with their timeouts, without closing anything
+// nor reporting them in the Status or the events. It verifies that the
+// teardown code paths work before they are needed.
+func (t *terminator) Rehearse(ctx context.Context) TerminationResult {
+	t.mu.Lock()
+	closers := executionOrder(t.closersStack)
+	t.mu.Unlock()
+
+	ctx = withValueStore(ctx)
+	result := TerminationResult{Reason: ReasonRehearsal}
+	for _, closer := range closers {
+		if closer.Rehearse == nil {
+			continue
+		}
+		if !t.config.enabled(&closer) {
+			result.Result = append(result.Result, TerminationResultData{Name: closer.Name, Status: SKIPPED})
+			continue
+		}
+
+		rehearsal := closer
+		rehearsal.Close = closer.Rehearse
+		rehearsal.critical = nil
+
+		termData := <-t.closeStack(ctx, &rehearsal, false)
+		if termData.Error != nil {
+			result.FailedOrTimeoutCount++
+		}
+		result.Result = append(result.Result, termData)
+	}
+
+	return result
+}
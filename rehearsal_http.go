@@ -0,0 +1,67 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// rehearsalData is the JSON representation of a rehearsed resource.
+type rehearsalData struct {
+	Name     string            `json:"name"`
+	Status   TerminationStatus `json:"status"`
+	Kind     ErrorKind         `json:"kind,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Duration string            `json:"duration"`
+}
+
+// RehearsalHandler returns an http.Handler to trigger rehearsals from an
+// admin endpoint. It accepts POST requests only, runs Rehearse with the
+// context of the request and responds with the results as JSON, with 200 OK
+// if every rehearsal succeeded and 500 Internal Server Error otherwise.
+// It responds with 503 Service Unavailable once the termination process has
+// started. The handler should not be exposed publicly.
+func RehearsalHandler(term Terminator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if term.State() != StateIdle {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+
+		result := term.Rehearse(r.Context())
+
+		data := make([]rehearsalData, 0, len(result.Result))
+		for _, termData := range result.Result {
+			entry := rehearsalData{
+				Name:     termData.Name,
+				Status:   termData.Status,
+				Kind:     termData.Kind,
+				Duration: termData.Duration.String(),
+			}
+			if termData.Error != nil {
+				entry.Error = termData.Error.Error()
+			}
+			data = append(data, entry)
+		}
+
+		code := http.StatusOK
+		if result.FailedOrTimeoutCount > 0 {
+			code = http.StatusInternalServerError
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(struct {
+			Failed int             `json:"failed"`
+			Result []rehearsalData `json:"result"`
+		}{result.FailedOrTimeoutCount, data})
+	})
+}
@@ -0,0 +1,72 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRehearsalHandler(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	handler := RehearsalHandler(term)
+
+	fail := false
+	term.AddWithOptions("db", func(ctx context.Context) error { return nil }, WithRehearsal(func(ctx context.Context) error {
+		if fail {
+			return errors.New("broken")
+		}
+		return nil
+	}))
+
+	post := func(method string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/rehearse", nil))
+		return rec
+	}
+
+	if rec := post(http.MethodGet); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+
+	rec := post(http.MethodPost)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", rec.Code)
+	}
+
+	var body struct {
+		Failed int `json:"failed"`
+		Result []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Unexpected body: %v", err)
+	}
+	if len(body.Result) != 1 || body.Result[0].Name != "db" || body.Result[0].Status != "SUCCESS" {
+		t.Errorf("Unexpected result %+v", body)
+	}
+
+	fail = true
+	if rec := post(http.MethodPost); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 on failed rehearsal, got %d", rec.Code)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if rec := post(http.MethodPost); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 after termination, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,49 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRehearse(t *testing.T) {
+	var events []Event
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithEventHandler(func(e Event) {
+		events = append(events, e)
+	}))
+
+	var closed, rehearsed []string
+	record := func(list *[]string, name string, err error) CloseFunc {
+		return func(ctx context.Context) error {
+			*list = append(*list, name)
+			return err
+		}
+	}
+
+	term.AddWithOptions("db", record(&closed, "db", nil), WithRehearsal(record(&rehearsed, "db", nil)))
+	term.AddWithOptions("cache", record(&closed, "cache", nil))
+	term.AddWithOptions("queue", record(&closed, "queue", nil),
+		WithRehearsal(record(&rehearsed, "queue", errors.New("broken"))), InPhase(PhaseDrain))
+
+	result := term.Rehearse(context.Background())
+
+	if result.Reason != ReasonRehearsal {
+		t.Errorf("Expected reason %q, got %q", ReasonRehearsal, result.Reason)
+	}
+	if len(closed) != 0 {
+		t.Errorf("Expected no resource to be closed, got %v", closed)
+	}
+	if len(rehearsed) != 2 || rehearsed[0] != "queue" || rehearsed[1] != "db" {
+		t.Errorf("Expected rehearsals in termination order, got %v", rehearsed)
+	}
+	if result.FailedOrTimeoutCount != 1 || result.Result[0].Status != FAILED {
+		t.Errorf("Expected the queue rehearsal to fail, got %+v", result)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events during a rehearsal, got %v", events)
+	}
+	if term.State() != StateIdle {
+		t.Errorf("Expected rehearsal to leave the terminator idle, got %v", term.State())
+	}
+}
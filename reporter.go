@@ -0,0 +1,73 @@
+package terminator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reporter reports the result of the termination once it completed, such as
+// to the standard error, a file or a chat webhook, see WithReporter.
+type Reporter interface {
+	Report(result TerminationResult) error
+}
+
+// ReporterFunc adapts a function to a Reporter.
+type ReporterFunc func(result TerminationResult) error
+
+// Report implements Reporter.
+func (f ReporterFunc) Report(result TerminationResult) error {
+	return f(result)
+}
+
+// WithReporter reports the result of the termination with the reporter once
+// the callback returned, and before Wait returns. The option can be given
+// several times, the reporters running one after another in order. Failures
+// are logged.
+func WithReporter(reporter Reporter) Option {
+	return func(c *config) {
+		c.reporters = append(c.reporters, reporter)
+	}
+}
+
+// WriterReporter writes the result to w as text: a line for the termination
+// followed by a line for every resource of TerminationResult.Result.
+func WriterReporter(w io.Writer) Reporter {
+	return ReporterFunc(func(result TerminationResult) error {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "terminator: termination (%s) completed in %v, %d failed or timed out\n", result.Reason, result.SLO.Duration, result.FailedOrTimeoutCount)
+		for _, termData := range result.Result {
+			fmt.Fprintf(&b, "  %s %s in %v", termData.Name, termData.Status, termData.Duration)
+			if termData.Error != nil {
+				fmt.Fprintf(&b, ": %v", termData.Error)
+			}
+			b.WriteByte('\n')
+		}
+
+		_, err := w.Write(b.Bytes())
+		return err
+	})
+}
+
+// StderrReporter writes the result to the standard error, see WriterReporter.
+func StderrReporter() Reporter {
+	return WriterReporter(os.Stderr)
+}
+
+// FileReporter writes the ExitSummary of the result as JSON to path, replaced
+// atomically, as WithSummaryFile does.
+func FileReporter(path string) Reporter {
+	return ReporterFunc(func(result TerminationResult) error {
+		return writeFileAtomic(path, newExitSummary(result, result.SLO.Duration, result.ExitCode()))
+	})
+}
+
+// report reports the result with every reporter, logging on failure.
+func (t *terminator) report(result TerminationResult) {
+	for _, reporter := range t.config.reporters {
+		if err := reporter.Report(result); err != nil {
+			t.config.logger.Printf("reporting the termination: %v", err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package terminator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReporters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var out bytes.Buffer
+	var order []string
+	path := filepath.Join(dir, "summary.json")
+	logger := &recordingLogger{}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger),
+		WithReporter(WriterReporter(&out)),
+		WithReporter(FileReporter(path)),
+		WithReporter(ReporterFunc(func(result TerminationResult) error {
+			order = append(order, "failing")
+			return errors.New("chat unavailable")
+		})),
+		WithReporter(ReporterFunc(func(result TerminationResult) error {
+			order = append(order, "last")
+			return nil
+		})),
+	)
+	term.SetCallback(func(result TerminationResult) { order = append(order, "callback") })
+	term.Add("db", func(ctx context.Context) error { return errors.New("connection reset") })
+	term.Add("cache", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(order) != 3 || order[0] != "callback" || order[2] != "last" {
+		t.Errorf("Expected the reporters to run in order after the callback, got %v", order)
+	}
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "chat unavailable") {
+		t.Errorf("Expected the failing reporter to be logged, got %v", logger.lines)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "1 failed") || !strings.Contains(lines[1], "cache SUCCESS") || !strings.Contains(lines[2], "db FAILED") {
+		t.Errorf("Unexpected text report:\n%s", out.String())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary ExitSummary
+	if err := json.Unmarshal(data, &summary); err != nil || summary.ExitCode != 1 || len(summary.Failed) != 1 {
+		t.Errorf("Unexpected file report %s: %v", data, err)
+	}
+}
@@ -0,0 +1,226 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRequestGrace sets how long the contexts returned by RequestContext
+// outlive the start of the termination, 10 seconds by default. It is the soft
+// deadline of the in-flight work, which keeps running once the service stops
+// taking new work until then.
+func WithRequestGrace(d time.Duration) Option {
+	return func(c *config) {
+		c.requestGrace = d
+	}
+}
+
+// WithDrainWindow makes the termination drain in two stages before closing
+// any resource. Draining is closed as the termination starts, telling the
+// work in flight to finish up; once window elapsed, or earlier if every
+// context returned by RequestContext is done, these contexts are canceled and
+// the resources are closed. The request grace period still cancels them
+// earlier if shorter.
+func WithDrainWindow(window time.Duration) Option {
+	return func(c *config) {
+		c.drainWindow = window
+	}
+}
+
+// WithPhaseDrainWindow drains the contexts returned by PhaseContext for the
+// phase in two stages like WithDrainWindow, just before the resources of the
+// phase are closed: they are canceled once window elapsed, or earlier if they
+// are all done.
+func WithPhaseDrainWindow(phase Phase, window time.Duration) Option {
+	return func(c *config) {
+		if c.phaseDrainWindows == nil {
+			c.phaseDrainWindows = make(map[Phase]time.Duration)
+		}
+		c.phaseDrainWindows[phase] = window
+	}
+}
+
+// drainStage tracks the shutdown-aware contexts canceled together.
+type drainStage struct {
+	tracker *Tracker
+
+	mu      sync.Mutex
+	next    uint64
+	cancels map[uint64]context.CancelFunc
+
+	// hard is closed once the contexts are canceled.
+	hard chan struct{}
+}
+
+// newDrainStage creates a stage without any context.
+func newDrainStage() *drainStage {
+	return &drainStage{
+		tracker: NewTracker(),
+		cancels: make(map[uint64]context.CancelFunc),
+		hard:    make(chan struct{}),
+	}
+}
+
+// context returns a context derived from parent, canceled with the stage.
+// Contexts created once the stage is draining are canceled right away.
+func (s *drainStage) context(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.hard:
+		cancel()
+		return ctx
+	default:
+	}
+
+	if !s.tracker.Begin() {
+		cancel()
+		return ctx
+	}
+
+	s.next++
+	id := s.next
+	s.cancels[id] = cancel
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		delete(s.cancels, id)
+		s.mu.Unlock()
+
+		s.tracker.Done()
+	}()
+
+	return ctx
+}
+
+// drain waits until the contexts of the stage are done, for window at most
+// or until abort is closed, and cancels them.
+func (s *drainStage) drain(window time.Duration, abort <-chan struct{}) {
+	if window > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), window)
+		go func() {
+			select {
+			case <-abort:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		s.tracker.Drain(ctx)
+		cancel()
+	}
+
+	s.cancel()
+}
+
+// cancel cancels the contexts of the stage.
+func (s *drainStage) cancel() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.hard:
+		return
+	default:
+	}
+
+	close(s.hard)
+	for _, cancel := range s.cancels {
+		cancel()
+	}
+}
+
+// Draining returns a channel closed once the termination starts, the soft
+// signal telling the work in flight to finish up.
+func (t *terminator) Draining() <-chan struct{} {
+	return t.shutdownChan
+}
+
+// RequestContext returns a context derived from parent for a unit of work,
+// such as a request, canceled once the request grace period elapsed after
+// the termination started, see WithRequestGrace, or at the end of the drain
+// window, see WithDrainWindow. This decouples stopping to take work, as the
+// termination starts, from cancelling the work in flight. The parent should
+// be done once the work completes, as the context of an HTTP request is, to
+// release the resources of the returned context.
+func (t *terminator) RequestContext(parent context.Context) context.Context {
+	return t.requests.context(parent)
+}
+
+// PhaseContext returns a context derived from parent for work that must stop
+// before the resources of the phase are closed, such as the handling of a
+// message before the broker connection closes. It is canceled just before
+// the phase is closed, at the end of its drain window if any, see
+// WithPhaseDrainWindow.
+func (t *terminator) PhaseContext(parent context.Context, phase Phase) context.Context {
+	return t.phaseStage(phase).context(parent)
+}
+
+// phaseStage returns the drain stage of the phase, creating it if needed.
+func (t *terminator) phaseStage(phase Phase) *drainStage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stage, ok := t.phaseStages[phase]
+	if !ok {
+		stage = newDrainStage()
+		t.phaseStages[phase] = stage
+	}
+
+	return stage
+}
+
+// startRequestGrace cancels the request contexts once the request grace
+// period elapsed, unless the drain window canceled them first.
+func (t *terminator) startRequestGrace() {
+	go func() {
+		timer := time.NewTimer(t.config.requestGrace)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			t.requests.cancel()
+		case <-t.requests.hard:
+		}
+	}()
+}
+
+// drainRequests waits for the drain window, if any, and cancels the request contexts.
+func (t *terminator) drainRequests() {
+	if t.config.drainWindow <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	remaining := time.Until(t.shutdownAt.Add(t.config.drainWindow))
+	t.mu.Unlock()
+
+	t.requests.drain(remaining, t.abortChan)
+}
+
+// drainPhase waits for the drain window of the phase, if any, and cancels its contexts.
+func (t *terminator) drainPhase(phase Phase) {
+	t.mu.Lock()
+	stage, ok := t.phaseStages[phase]
+	t.mu.Unlock()
+
+	if ok {
+		stage.drain(t.config.phaseDrainWindows[phase], t.abortChan)
+	}
+}
+
+// cancelPhaseStages cancels the phase contexts left, once the termination completed.
+func (t *terminator) cancelPhaseStages() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, stage := range t.phaseStages {
+		stage.cancel()
+	}
+}
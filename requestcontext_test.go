@@ -0,0 +1,54 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRequestContext(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithRequestGrace(100*time.Millisecond))
+
+	ctx := term.RequestContext(context.Background())
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("The request context shouldn't be canceled before the termination")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	started := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+
+	select {
+	case <-ctx.Done():
+		if elapsed := time.Since(started); elapsed < 100*time.Millisecond {
+			t.Errorf("Expected the request context to outlive the start of the termination, canceled after %v", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The request context should be canceled once the grace period elapsed")
+	}
+
+	late := term.RequestContext(context.Background())
+	select {
+	case <-late.Done():
+	case <-time.After(50 * time.Millisecond):
+		t.Error("Request contexts created after the grace period should be canceled right away")
+	}
+}
+
+func TestRequestContextParentDone(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := term.RequestContext(parent)
+	cancel()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("The request context should be canceled with its parent")
+	}
+}
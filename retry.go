@@ -0,0 +1,51 @@
+package terminator
+
+import "context"
+
+// RetryFailed closes again the resources that failed, for a best-effort
+// second pass from the callback, and returns the merged result. The retries
+// run one at a time with ctx, which should be bounded by the remaining
+// budget, and with the timeouts of the resources. Panicking closers are not
+// retried, and nothing is retried once the termination was aborted or while
+// it is still running.
+func (r TerminationResult) RetryFailed(ctx context.Context) TerminationResult {
+	retried := r
+	retried.Result = make([]TerminationResultData, len(r.Result))
+	copy(retried.Result, r.Result)
+	retried.Summary = r.Summary.clone()
+
+	if r.term == nil || r.Partial || r.Aborted {
+		return retried
+	}
+
+	retried.FailedOrTimeoutCount = 0
+	for index := range retried.Result {
+		position := index
+		if r.indexes != nil {
+			position = r.indexes[index]
+		}
+
+		if retryable(retried.Result[index]) && position < len(r.closers) && ctx.Err() == nil {
+			closer := r.closers[position]
+
+			termData := <-r.term.closeStack(ctx, &closer, false)
+			termData.Retried = true
+			if retried.Summary != nil {
+				retried.Summary.Counts[retried.Result[index].Status]--
+				retried.Summary.Counts[termData.Status]++
+			}
+			retried.Result[index] = termData
+		}
+
+		if retried.Result[index].Error != nil {
+			retried.FailedOrTimeoutCount++
+		}
+	}
+
+	return retried
+}
+
+// retryable reports whether closing the resource again is meaningful.
+func retryable(data TerminationResultData) bool {
+	return data.Status == FAILED && data.Kind != ErrorKindPanic
+}
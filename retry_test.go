@@ -0,0 +1,65 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRetryFailed(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	attempts := map[string]int{}
+	flaky := func(name string, failures int) CloseFunc {
+		return func(ctx context.Context) error {
+			attempts[name]++
+			if attempts[name] <= failures {
+				return errors.New("transient")
+			}
+			return nil
+		}
+	}
+
+	term.Add("db", flaky("db", 0))
+	term.Add("cache", flaky("cache", 1))
+	term.Add("queue", flaky("queue", 2))
+	term.Add("exporter", func(ctx context.Context) error {
+		attempts["exporter"]++
+		panic("broken")
+	})
+
+	retried := make(chan TerminationResult, 1)
+	term.SetCallback(func(result TerminationResult) {
+		retried <- result.RetryFailed(context.Background())
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result := <-retried
+	if attempts["db"] != 1 || attempts["cache"] != 2 || attempts["queue"] != 2 || attempts["exporter"] != 1 {
+		t.Errorf("Expected only the failed closers to be retried once, got %v", attempts)
+	}
+
+	expected := map[string]TerminationStatus{"exporter": FAILED, "queue": FAILED, "cache": SUCCESS, "db": SUCCESS}
+	for _, termData := range result.Result {
+		if termData.Status != expected[termData.Name] {
+			t.Errorf("%s: expected %v, got %v", termData.Name, expected[termData.Name], termData.Status)
+		}
+		if termData.Retried != (termData.Name == "cache" || termData.Name == "queue") {
+			t.Errorf("%s: unexpected retried flag", termData.Name)
+		}
+	}
+
+	if result.FailedOrTimeoutCount != 2 {
+		t.Errorf("Expected 2 failures after the retry, got %d", result.FailedOrTimeoutCount)
+	}
+
+	if original, _ := term.Result(); original.FailedOrTimeoutCount != 3 {
+		t.Errorf("The retry shouldn't change the result of the terminator, got %d failures", original.FailedOrTimeoutCount)
+	}
+}
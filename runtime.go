@@ -0,0 +1,121 @@
+package terminator
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// Runtime is the kind of environment the process runs in, which decides how
+// it is asked to terminate and how long it has, see DetectRuntime.
+type Runtime string
+
+// Runtimes detected by DetectRuntime.
+const (
+	// RuntimeKubernetes is a container of a Kubernetes pod, sent SIGTERM and
+	// killed after terminationGracePeriodSeconds, 30 seconds by default.
+	RuntimeKubernetes Runtime = "kubernetes"
+
+	// RuntimeContainer is another container, such as one run by Docker, sent
+	// SIGTERM and killed after 10 seconds by default.
+	RuntimeContainer Runtime = "container"
+
+	// RuntimeSystemd is a systemd unit, sent SIGTERM and killed after
+	// TimeoutStopSec, 90 seconds by default.
+	RuntimeSystemd Runtime = "systemd"
+
+	// RuntimeTerminal is a program run from a terminal, interrupted with
+	// Ctrl-C or hung up when the terminal closes, whose user expects it to
+	// exit promptly.
+	RuntimeTerminal Runtime = "terminal"
+
+	// RuntimeUnknown is any other environment.
+	RuntimeUnknown Runtime = "unknown"
+)
+
+// containerMarkers are files created by the container runtimes in their containers.
+var containerMarkers = []string{"/.dockerenv", "/run/.containerenv"}
+
+// DetectRuntime detects the runtime of the process from its environment:
+// the variables set by Kubernetes, container runtimes and systemd, the
+// marker files of container runtimes, and whether the standard input is a
+// terminal, in this order.
+func DetectRuntime() Runtime {
+	if _, ok := os.LookupEnv("KUBERNETES_SERVICE_HOST"); ok {
+		return RuntimeKubernetes
+	}
+	if _, ok := os.LookupEnv("container"); ok {
+		return RuntimeContainer
+	}
+	for _, marker := range containerMarkers {
+		if _, err := os.Stat(marker); err == nil {
+			return RuntimeContainer
+		}
+	}
+	if _, ok := os.LookupEnv("INVOCATION_ID"); ok {
+		return RuntimeSystemd
+	}
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return RuntimeTerminal
+	}
+
+	return RuntimeUnknown
+}
+
+// RuntimePreset holds sensible defaults for a runtime, see Preset.
+type RuntimePreset struct {
+	Runtime Runtime
+
+	// Signals asking the process to terminate in the runtime
+	Signals []os.Signal
+
+	// GracePeriod after which the runtime kills the process by default, see WithGracePeriod
+	GracePeriod time.Duration
+
+	// GracePeriodEnv overrides GracePeriod when set, see WithGracePeriodFromEnv
+	GracePeriodEnv string
+}
+
+// Preset returns the defaults of the runtime.
+func (r Runtime) Preset() RuntimePreset {
+	preset := RuntimePreset{
+		Runtime:     r,
+		Signals:     []os.Signal{os.Interrupt, syscall.SIGTERM},
+		GracePeriod: 30 * time.Second,
+	}
+
+	switch r {
+	case RuntimeKubernetes:
+		preset.GracePeriodEnv = DefaultGracePeriodEnv
+	case RuntimeContainer:
+		preset.GracePeriod = 10 * time.Second
+		preset.GracePeriodEnv = DefaultGracePeriodEnv
+	case RuntimeSystemd:
+		preset.GracePeriod = 90 * time.Second
+	case RuntimeTerminal:
+		preset.Signals = append(preset.Signals, hangupSignals...)
+		preset.GracePeriod = 5 * time.Second
+	}
+
+	return preset
+}
+
+// Options returns the options applying the grace period of the preset.
+func (p RuntimePreset) Options() []Option {
+	opts := []Option{WithGracePeriod(p.GracePeriod)}
+	if p.GracePeriodEnv != "" {
+		opts = append(opts, WithGracePeriodFromEnv(p.GracePeriodEnv))
+	}
+
+	return opts
+}
+
+// NewTerminatorForRuntime creates a terminator with the signals and the
+// grace period of the runtime detected with DetectRuntime, instead of
+// copying them from one service to another. The options given are applied
+// after the preset, so they override it.
+func NewTerminatorForRuntime(opts ...Option) Terminator {
+	preset := DetectRuntime().Preset()
+
+	return NewTerminator(preset.Signals, append(preset.Options(), opts...)...)
+}
@@ -0,0 +1,91 @@
+package terminator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDetectRuntime(t *testing.T) {
+	for _, key := range []string{"KUBERNETES_SERVICE_HOST", "container", "INVOCATION_ID"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Skipf("%s is set in the test environment", key)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "runtime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	marker := filepath.Join(dir, ".dockerenv")
+	defer func(markers []string) { containerMarkers = markers }(containerMarkers)
+	containerMarkers = []string{marker}
+
+	if runtime := DetectRuntime(); runtime == RuntimeKubernetes || runtime == RuntimeContainer || runtime == RuntimeSystemd {
+		t.Errorf("Expected no runtime to be detected from the environment, got %s", runtime)
+	}
+
+	setEnv(t, map[string]string{"INVOCATION_ID": "0123"})
+	if runtime := DetectRuntime(); runtime != RuntimeSystemd {
+		t.Errorf("Expected the systemd runtime, got %s", runtime)
+	}
+
+	if err := ioutil.WriteFile(marker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if runtime := DetectRuntime(); runtime != RuntimeContainer {
+		t.Errorf("Expected the container runtime, got %s", runtime)
+	}
+
+	setEnv(t, map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"})
+	if runtime := DetectRuntime(); runtime != RuntimeKubernetes {
+		t.Errorf("Expected the kubernetes runtime, got %s", runtime)
+	}
+}
+
+func TestRuntimePreset(t *testing.T) {
+	tests := []struct {
+		runtime     Runtime
+		gracePeriod time.Duration
+		env         string
+	}{
+		{RuntimeKubernetes, 30 * time.Second, DefaultGracePeriodEnv},
+		{RuntimeContainer, 10 * time.Second, DefaultGracePeriodEnv},
+		{RuntimeSystemd, 90 * time.Second, ""},
+		{RuntimeTerminal, 5 * time.Second, ""},
+		{RuntimeUnknown, 30 * time.Second, ""},
+	}
+
+	for _, test := range tests {
+		preset := test.runtime.Preset()
+		if preset.GracePeriod != test.gracePeriod || preset.GracePeriodEnv != test.env {
+			t.Errorf("Unexpected preset for %s: %+v", test.runtime, preset)
+		}
+		if len(preset.Signals) < 2 || preset.Signals[0] != os.Interrupt || preset.Signals[1] != syscall.SIGTERM {
+			t.Errorf("Expected %s to catch os.Interrupt and SIGTERM, got %v", test.runtime, preset.Signals)
+		}
+	}
+
+	if signals := RuntimeTerminal.Preset().Signals; len(signals) != 2+len(hangupSignals) {
+		t.Errorf("Expected the terminal to catch the hangup signals, got %v", signals)
+	}
+}
+
+func TestNewTerminatorForRuntime(t *testing.T) {
+	setEnv(t, map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1", DefaultGracePeriodEnv: "20"})
+
+	config := NewTerminatorForRuntime().(*terminator).config
+	if expected := NewTerminator(nil, WithGracePeriod(20*time.Second)).(*terminator).config; config.shutdownBudget != expected.shutdownBudget {
+		t.Errorf("Expected the grace period from the environment, got a budget of %v", config.shutdownBudget)
+	}
+
+	config = NewTerminatorForRuntime(WithShutdownBudget(time.Second)).(*terminator).config
+	if config.shutdownBudget != time.Second {
+		t.Errorf("Expected the options to override the preset, got a budget of %v", config.shutdownBudget)
+	}
+}
@@ -0,0 +1,65 @@
+package terminator
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// WithGCSafetyNet watches the resources registered as Critical, and logs
+// loudly when one is garbage collected without its closer having run nor its
+// registration having been removed, as it escaped the shutdown path: its
+// closer may close another object, or the terminator it was registered with
+// may have been dropped without terminating. The safety net relies on
+// runtime.AddCleanup and is only available when built with Go 1.24 or later.
+func WithGCSafetyNet() Option {
+	return func(c *config) {
+		c.gcSafetyNet = true
+	}
+}
+
+// Critical flags the resource as critical, with the object it closes, such
+// as the *sql.DB of a pool, watched by the safety net enabled by
+// WithGCSafetyNet. resource must be a pointer, and it is not retained.
+// Without the safety net, Critical has no effect.
+func Critical(resource interface{}) CloserOption {
+	return func(p *payload) {
+		p.resource = resource
+	}
+}
+
+// criticalState tracks whether a critical resource was closed or removed.
+type criticalState struct {
+	settled uint32
+}
+
+// settle records that the critical resource, if any, no longer needs the
+// safety net, as it was closed or handed back to the application.
+func (p *payload) settle() {
+	if p.critical != nil {
+		atomic.StoreUint32(&p.critical.settled, 1)
+	}
+}
+
+// watchCritical attaches the safety net to the resource of the closer, which
+// it detaches from the closer so that the closers stack does not retain it.
+func (t *terminator) watchCritical(closer *payload) {
+	resource := closer.resource
+	closer.resource = nil
+	if resource == nil || !t.config.gcSafetyNet || !cleanupSupported {
+		return
+	}
+
+	if value := reflect.ValueOf(resource); value.Kind() != reflect.Ptr || value.IsNil() {
+		t.config.logger.Printf("critical resource %q is not watched by the safety net: %T is not a pointer", closer.Name, resource)
+		return
+	}
+
+	state := &criticalState{}
+	name := closer.Name
+	addCleanup(resource, func() {
+		if atomic.LoadUint32(&state.settled) == 0 {
+			t.config.logger.Printf("CRITICAL: resource %q was garbage collected without ever having been closed: it escaped the shutdown path", name)
+		}
+	})
+	closer.critical = state
+}
@@ -0,0 +1,19 @@
+//go:build go1.24
+// +build go1.24
+
+package terminator
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// cleanupSupported reports whether addCleanup watches the resources.
+const cleanupSupported = true
+
+// addCleanup runs cleanup once the object resource points to is unreachable.
+func addCleanup(resource interface{}, cleanup func()) {
+	runtime.AddCleanup((*byte)(reflect.ValueOf(resource).UnsafePointer()), func(cleanup func()) {
+		cleanup()
+	}, cleanup)
+}
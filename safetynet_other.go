@@ -0,0 +1,11 @@
+//go:build !go1.24
+// +build !go1.24
+
+package terminator
+
+// cleanupSupported reports whether addCleanup watches the resources:
+// runtime.AddCleanup is not available before Go 1.24.
+const cleanupSupported = false
+
+// addCleanup does nothing before Go 1.24.
+func addCleanup(resource interface{}, cleanup func()) {}
@@ -0,0 +1,58 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pool stands for a critical resource, large enough not to be a tiny allocation.
+type pool struct {
+	conns [64]byte
+}
+
+func TestGCSafetyNet(t *testing.T) {
+	if !cleanupSupported {
+		t.Skip("the safety net requires Go 1.24")
+	}
+
+	logger := make(lineLogger, 1)
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithGCSafetyNet())
+
+	noop := func(ctx context.Context) error { return nil }
+	term.AddWithOptions("escaped", noop, Critical(&pool{}))
+	handle, _ := term.AddWithOptions("removed", noop, Critical(&pool{}))
+	handle.Remove()
+	term.AddWithOptions("closed", noop, Critical(&pool{}))
+	term.CloseNow(context.Background(), "closed")
+
+	timeout := time.After(time.Second)
+	for {
+		runtime.GC()
+
+		select {
+		case line := <-logger:
+			if !strings.Contains(line, "escaped") {
+				t.Errorf("Expected a warning about the escaped resource, got %q", line)
+			}
+			return
+		case <-timeout:
+			t.Fatal("Expected a warning about the critical resource never closed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestCriticalNotPointer(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithGCSafetyNet())
+
+	term.AddWithOptions("pool", func(ctx context.Context) error { return nil }, Critical(pool{}))
+
+	if cleanupSupported && (len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "not a pointer")) {
+		t.Errorf("Expected a warning about the resource not being a pointer, got %q", logger.lines)
+	}
+}
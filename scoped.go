@@ -0,0 +1,45 @@
+package terminator
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// scope is the registration of a resource added with AddScoped.
+type scope struct {
+	handle   *Handle
+	released uint32
+}
+
+// AddScoped registers a short-lived resource, such as a connection, and
+// returns the function removing it once the application closed it itself.
+// Scoped resources that are never released make the closers stack grow
+// without bound, so a warning is logged when the release function is garbage
+// collected without having been called before the termination started.
+// Release is safe to call more than once, and does nothing once the
+// termination started, as the resource is then closed by the termination.
+// The error of the registration is returned, as by Add, with a nil release
+// function.
+func (t *terminator) AddScoped(name string, close CloseFunc, opts ...CloserOption) (release func(), err error) {
+	handle, err := t.AddWithOptions(name, close, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scope{handle: handle}
+	runtime.SetFinalizer(s, func(s *scope) {
+		if atomic.LoadUint32(&s.released) == 0 && !t.IsShuttingDown() {
+			t.config.logger.Printf("scoped resource %q was never released: its closer is kept until the termination", name)
+		}
+	})
+
+	return s.release, nil
+}
+
+// release removes the resource from the closers stack, the first time only.
+func (s *scope) release() {
+	if atomic.CompareAndSwapUint32(&s.released, 0, 1) {
+		s.handle.Remove()
+		runtime.SetFinalizer(s, nil)
+	}
+}
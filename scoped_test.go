@@ -0,0 +1,88 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lineLogger delivers the logged lines on a channel, for warnings logged from other goroutines.
+type lineLogger chan string
+
+func (l lineLogger) Printf(format string, v ...interface{}) {
+	select {
+	case l <- fmt.Sprintf(format, v...):
+	default:
+	}
+}
+
+func TestAddScoped(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var closed []string
+	closer := func(name string) CloseFunc {
+		return func(ctx context.Context) error {
+			closed = append(closed, name)
+			return nil
+		}
+	}
+
+	release, err := term.AddScoped("conn-1", closer("conn-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	term.AddScoped("conn-2", closer("conn-2"))
+	release()
+	release()
+
+	if registered := term.Status().Registered; registered != 1 {
+		t.Errorf("Expected 1 registered resource, got %d", registered)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(closed) != 1 || closed[0] != "conn-2" {
+		t.Errorf("Expected only the scoped resource not released to be closed, got %v", closed)
+	}
+}
+
+func TestAddScopedNeverReleased(t *testing.T) {
+	logger := make(lineLogger, 1)
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger))
+
+	term.AddScoped("leaked", func(ctx context.Context) error { return nil })
+	release, _ := term.AddScoped("released", func(ctx context.Context) error { return nil })
+	release()
+
+	timeout := time.After(time.Second)
+	for {
+		runtime.GC()
+
+		select {
+		case line := <-logger:
+			if !strings.Contains(line, "leaked") {
+				t.Errorf("Expected a warning about the leaked resource, got %q", line)
+			}
+			return
+		case <-timeout:
+			t.Fatal("Expected a warning about the scoped resource never released")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestAddScopedRejected(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+	term.Seal()
+
+	if release, err := term.AddScoped("conn", func(ctx context.Context) error { return nil }); err != ErrSealed || release != nil {
+		t.Errorf("Expected ErrSealed from AddScoped, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSeal(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger))
+	noop := func(ctx context.Context) error { return nil }
+
+	pool, err := term.Add("pool", noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	term.Seal()
+
+	if handle, err := term.Add("lazy cache", noop); err != ErrSealed || handle != nil {
+		t.Errorf("Expected ErrSealed, got %v", err)
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("Expected the late registration to be logged, got %v", logger.lines)
+	}
+
+	if err := pool.Reopen(noop); err != nil {
+		t.Errorf("Reopening a registered resource should be allowed, got %v", err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Reopen(noop); err != ErrSealed {
+		t.Errorf("Expected ErrSealed for a closed resource, got %v", err)
+	}
+}
+
+func TestSealWhenClosing(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}))
+
+	var lateErr error
+	term.Add("db", func(ctx context.Context) error {
+		_, lateErr = term.Add("late", func(ctx context.Context) error { return nil })
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if lateErr != ErrSealed {
+		t.Errorf("Expected registrations during the termination to be rejected, got %v", lateErr)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(make(lineLogger, 100)))
+
+	var mu sync.Mutex
+	closed := 0
+	closer := func(ctx context.Context) error {
+		mu.Lock()
+		closed++
+		mu.Unlock()
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			term.AddWithTimeout(fmt.Sprintf("conn-%d", i), closer, time.Second)
+		}(i)
+	}
+	wg.Wait()
+
+	if registered := term.Status().Registered; registered != 50 {
+		t.Fatalf("Expected the 50 resources to be registered, got %d", registered)
+	}
+
+	// Registrations racing with the termination are either closed or rejected.
+	rejected := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		go func(i int) {
+			_, err := term.Add(fmt.Sprintf("late-%d", i), closer)
+			rejected <- err
+		}(i)
+	}
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	accepted := 50
+	for i := 0; i < 50; i++ {
+		if err := <-rejected; err == ErrSealed {
+			accepted--
+		} else if err != nil {
+			t.Errorf("Unexpected registration error: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if closed != 50+accepted {
+		t.Errorf("Expected the %d accepted resources to be closed, got %d", 50+accepted, closed)
+	}
+}
+
+func TestCloseLateRegistrations(t *testing.T) {
+	sealed := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithCloseLateRegistrations())
+	t.Cleanup(func() {
+		sealed.Terminate("test done")
+		if !sealed.Wait(time.Second) {
+			t.Error("Termination of the sealed terminator timed out")
+		}
+	})
+
+	sealed.Seal()
+	if _, err := sealed.Add("before", func(ctx context.Context) error { return nil }); err != ErrSealed {
+		t.Errorf("Expected registrations sealed before the termination to be rejected only, got %v", err)
+	}
+
+	var order []string
+	var lateErr error
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithCloseLateRegistrations())
+	term.Add("db", func(ctx context.Context) error {
+		_, lateErr = term.Add("late", func(ctx context.Context) error {
+			order = append(order, "late")
+			return nil
+		})
+		order = append(order, "db")
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if lateErr != ErrClosedOnRegistration || !errors.Is(lateErr, ErrSealed) {
+		t.Errorf("Expected ErrClosedOnRegistration, got %v", lateErr)
+	}
+	if len(order) != 2 || order[0] != "late" {
+		t.Errorf("Expected the late resource to be closed on registration, got %v", order)
+	}
+}
@@ -0,0 +1,99 @@
+package terminator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// SelfTestReport holds the diagnostics of SelfTest.
+type SelfTestReport struct {
+
+	// PID of the process
+	PID int
+
+	// Close signals the terminator is subscribed to
+	Signals []os.Signal
+
+	// External is set for terminators fed by NewTerminatorFromChannel
+	External bool
+
+	// DeliveryChecked is set when the delivery of signals to the process could be probed,
+	// which is not supported on every platform
+	DeliveryChecked bool
+
+	// Delivered is set when the probe signal sent to the process was received
+	Delivered bool
+
+	// Problems preventing the termination from being triggered by a signal
+	Problems []string
+
+	// Notes about the signal wiring that may deserve attention
+	Notes []string
+}
+
+// OK reports whether no problem was found.
+func (r SelfTestReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Err returns an error listing the problems found, nil if there are none.
+func (r SelfTestReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+
+	return fmt.Errorf("terminator: signal self-test failed: %s", strings.Join(r.Problems, "; "))
+}
+
+// SelfTest verifies in-process that the signals reach the process and the
+// terminator listens to them, without triggering the termination. It is
+// meant to be called at startup, to catch platforms and containers where the
+// signal delivery is misconfigured. Where supported, it sends SIGCHLD to the
+// process as a probe and waits up to timeout for it: its default action is
+// to be ignored, and its handlers already expect spurious deliveries as they
+// coalesce.
+func (t *terminator) SelfTest(timeout time.Duration) SelfTestReport {
+	t.ensureMonitor()
+
+	report := SelfTestReport{
+		PID:      os.Getpid(),
+		Signals:  t.signals,
+		External: t.externalChan != nil,
+	}
+
+	if !report.External && len(report.Signals) == 0 {
+		report.Problems = append(report.Problems, "no close signal is subscribed, the termination can only be triggered programmatically")
+	}
+
+	if state := t.State(); state != StateIdle {
+		report.Problems = append(report.Problems, fmt.Sprintf("the termination already started, in state %v", state))
+	}
+
+	for _, sig := range report.Signals {
+		var others []string
+		for _, owner := range signalOwners(sig) {
+			if owner != "terminator" {
+				others = append(others, owner)
+			}
+		}
+
+		if len(others) > 0 {
+			report.Notes = append(report.Notes, fmt.Sprintf("signal %v is also handled by %s", sig, strings.Join(others, ", ")))
+		}
+	}
+
+	if report.PID == 1 {
+		report.Notes = append(report.Notes, "running as PID 1, signals without a handler are ignored and orphaned processes are not reaped")
+	}
+
+	checked, err := probeSignalDelivery(report.Signals, timeout)
+	report.DeliveryChecked = checked
+	report.Delivered = checked && err == nil
+	if checked && err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+
+	return report
+}
@@ -0,0 +1,14 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package terminator
+
+import (
+	"os"
+	"time"
+)
+
+// probeSignalDelivery is not supported on this platform, as a process cannot signal itself.
+func probeSignalDelivery(closeSignals []os.Signal, timeout time.Duration) (bool, error) {
+	return false, nil
+}
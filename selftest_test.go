@@ -0,0 +1,57 @@
+package terminator
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelfTest(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	unregister := RegisterSignalHandler("library", os.Interrupt)
+	defer unregister()
+
+	report := term.SelfTest(time.Second)
+
+	if !report.OK() || report.Err() != nil {
+		t.Errorf("Expected no problem, got %v", report.Problems)
+	}
+	if report.DeliveryChecked && !report.Delivered {
+		t.Error("Expected the probe signal to be delivered")
+	}
+	if len(report.Signals) != 1 || report.Signals[0] != os.Interrupt {
+		t.Errorf("Expected the subscription to os.Interrupt, got %v", report.Signals)
+	}
+	if len(report.Notes) == 0 || !strings.Contains(report.Notes[0], "library") {
+		t.Errorf("Expected a note about the library handler, got %v", report.Notes)
+	}
+
+	if term.State() != StateIdle {
+		t.Errorf("SelfTest shouldn't trigger the termination, got state %v", term.State())
+	}
+}
+
+func TestSelfTestProblems(t *testing.T) {
+	term := NewTerminator(nil, WithRegistrationGrace(0))
+
+	report := term.SelfTest(time.Second)
+	if report.OK() || !strings.Contains(report.Err().Error(), "no close signal") {
+		t.Errorf("Expected a problem about the missing signals, got %v", report.Problems)
+	}
+
+	external := NewTerminatorFromChannel(make(chan os.Signal), WithRegistrationGrace(0))
+	if report := external.SelfTest(time.Second); !report.OK() || !report.External {
+		t.Errorf("Expected terminators fed by a channel to pass, got %+v", report)
+	}
+
+	term.(*terminator).triggerChan <- trigger{reason: ReasonInternalFailure}
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if report := term.SelfTest(time.Second); len(report.Problems) != 2 {
+		t.Errorf("Expected a problem about the completed termination, got %v", report.Problems)
+	}
+}
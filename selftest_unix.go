@@ -0,0 +1,52 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package terminator
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// probeSignal is sent to the process by SelfTest. Its default action is to
+// be ignored and, unlike SIGURG, the Go runtime never sends it, so that its
+// receipt proves the delivery of the probe. As deliveries of SIGCHLD
+// coalesce, its handlers, such as the reapers of the processes running as
+// PID 1, already expect spurious ones, unlike those of SIGWINCH that redraw
+// a terminal UI.
+const probeSignal = syscall.SIGCHLD
+
+// sendProbe sends the probe signal to the process, replaced in tests.
+var sendProbe = func() error { return syscall.Kill(os.Getpid(), probeSignal) }
+
+// probeSignalDelivery sends the probe signal to the process and waits up to
+// timeout for it. The probe is not checked when the close signals include
+// it, as it would trigger the termination.
+func probeSignalDelivery(closeSignals []os.Signal, timeout time.Duration) (bool, error) {
+	for _, sig := range closeSignals {
+		if sig == probeSignal {
+			return false, nil
+		}
+	}
+
+	probe := make(chan os.Signal, 1)
+	signal.Notify(probe, probeSignal)
+	defer signal.Stop(probe)
+
+	if err := sendProbe(); err != nil {
+		return true, fmt.Errorf("sending the probe signal %v failed: %w", probeSignal, err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-probe:
+		return true, nil
+	case <-timer.C:
+		return true, fmt.Errorf("the probe signal %v was not delivered within %v", probeSignal, timeout)
+	}
+}
@@ -0,0 +1,172 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Phases used by NewWebService and NewWorkerService. Resources registered
+// without a phase are closed in DefaultPhase, after the intake has stopped
+// and before the telemetry is flushed.
+const (
+	// PhaseReadiness runs the readiness hooks, reporting the service as not ready.
+	PhaseReadiness Phase = -500
+
+	// PhasePreStop waits for load balancers to notice the service is not ready.
+	PhasePreStop Phase = -400
+
+	// PhaseServer stops accepting connections, drains in-flight requests and closes the server.
+	PhaseServer Phase = -300
+
+	// PhaseIntake stops consuming new messages.
+	PhaseIntake Phase = -300
+
+	// PhaseDrain waits for the in-flight handlers to complete.
+	PhaseDrain Phase = -200
+
+	// PhaseCommit commits or acknowledges the handled messages.
+	PhaseCommit Phase = -100
+
+	// PhaseBroker closes the broker connections.
+	PhaseBroker Phase = 100
+
+	// PhaseTelemetry flushes telemetry and logs once everything else is closed.
+	PhaseTelemetry Phase = 1000
+)
+
+// defaultDrainTimeout is how long in-flight work is drained before giving up.
+const defaultDrainTimeout = 10 * time.Second
+
+// ServiceOption configures the terminator created by NewWebService or NewWorkerService.
+// Options specific to one kind of service are ignored by the other.
+type ServiceOption func(*serviceConfig)
+
+// phaseCloser is a closer registered in a given phase by a service preset.
+type phaseCloser struct {
+	name  string
+	close CloseFunc
+	phase Phase
+}
+
+// serviceConfig holds the settings applied through ServiceOption values.
+type serviceConfig struct {
+	signals        []os.Signal
+	options        []Option
+	readinessHooks []func()
+	preStopDelay   time.Duration
+	drainTimeout   time.Duration
+	closers        []phaseCloser
+}
+
+// WithServiceSignals sets the signals triggering the termination, os.Interrupt and SIGTERM by default.
+func WithServiceSignals(signals ...os.Signal) ServiceOption {
+	return func(c *serviceConfig) {
+		c.signals = signals
+	}
+}
+
+// WithServiceOptions sets the options of the underlying terminator.
+func WithServiceOptions(opts ...Option) ServiceOption {
+	return func(c *serviceConfig) {
+		c.options = append(c.options, opts...)
+	}
+}
+
+// WithReadinessHook adds a hook run in PhaseReadiness, for example to fail an
+// external health check. ReadinessHandler reports the service as not ready on
+// its own as soon as the termination starts.
+func WithReadinessHook(hook func()) ServiceOption {
+	return func(c *serviceConfig) {
+		c.readinessHooks = append(c.readinessHooks, hook)
+	}
+}
+
+// WithPreStopDelay sets how long to wait in PhasePreStop, before the server
+// stops accepting connections, giving load balancers time to stop routing
+// traffic to the service. There is no delay by default.
+func WithPreStopDelay(d time.Duration) ServiceOption {
+	return func(c *serviceConfig) {
+		c.preStopDelay = d
+	}
+}
+
+// WithDrainTimeout sets how long in-flight work is drained, 10 seconds by
+// default. A web service closes the server forcibly once it elapses.
+func WithDrainTimeout(d time.Duration) ServiceOption {
+	return func(c *serviceConfig) {
+		c.drainTimeout = d
+	}
+}
+
+// WithTelemetryFlush adds a closer run in PhaseTelemetry to flush telemetry or logs.
+func WithTelemetryFlush(name string, flush CloseFunc) ServiceOption {
+	return func(c *serviceConfig) {
+		c.closers = append(c.closers, phaseCloser{name: name, close: flush, phase: PhaseTelemetry})
+	}
+}
+
+// newServiceConfig applies the options over the defaults of the service presets.
+func newServiceConfig(opts []ServiceOption) serviceConfig {
+	config := serviceConfig{
+		signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+		drainTimeout: defaultDrainTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+// newTerminator creates the terminator of a service preset with the readiness
+// and pre-stop phases registered, and returns the error of the registrations.
+func (config *serviceConfig) newTerminator() (Terminator, error) {
+	term := NewTerminator(config.signals, config.options...)
+
+	if len(config.readinessHooks) > 0 {
+		_, err := term.AddWithOptions("readiness", func(ctx context.Context) error {
+			for _, hook := range config.readinessHooks {
+				hook()
+			}
+			return nil
+		}, InPhase(PhaseReadiness))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.preStopDelay > 0 {
+		_, err := term.AddWithOptions("pre-stop delay", func(ctx context.Context) error {
+			timer := time.NewTimer(config.preStopDelay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, InPhase(PhasePreStop))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return term, nil
+}
+
+// addClosers registers the closers configured through the options in their
+// phases. Closers of the same phase are closed in reverse order of the options.
+// It returns the error of the first registration that failed.
+func (config *serviceConfig) addClosers(term Terminator) error {
+	for _, closer := range config.closers {
+		if _, err := term.AddWithOptions(closer.name, closer.close, InPhase(closer.phase)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
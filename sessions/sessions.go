@@ -0,0 +1,184 @@
+// Package sessions tracks the outstanding sessions of tooling-style daemons,
+// such as SSH sessions or remote exec streams, and closes them on shutdown
+// after telling the remote side why.
+//
+// The package does not depend on any SSH implementation: sessions are
+// io.Closer values, notified through the methods they provide. The channels
+// and sessions of golang.org/x/crypto/ssh and github.com/gliderlabs/ssh are
+// supported as they are.
+package sessions
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// DefaultMessage is the notification written to the sessions on shutdown.
+const DefaultMessage = "server is shutting down\r\n"
+
+// stderrer is implemented by sessions with a standard error stream, such as
+// ssh.Channel, to which the notification is written.
+type stderrer interface {
+	Stderr() io.ReadWriter
+}
+
+// exiter is implemented by sessions reporting an exit status while closing,
+// such as the sessions of github.com/gliderlabs/ssh.
+type exiter interface {
+	Exit(code int) error
+}
+
+// requestSender is implemented by sessions sending requests to the remote
+// side, such as ssh.Channel, which report the exit status with a request.
+type requestSender interface {
+	SendRequest(name string, wantReply bool, payload []byte) (bool, error)
+}
+
+// Option configures Sessions.
+type Option func(*Sessions)
+
+// WithMessage sets the notification written to the sessions on shutdown,
+// DefaultMessage by default. An empty message disables the notification.
+func WithMessage(message string) Option {
+	return func(s *Sessions) {
+		s.message = message
+	}
+}
+
+// WithExitStatus reports status as the exit status of the sessions closed
+// on shutdown, for sessions that support it. No exit status is reported by
+// default.
+func WithExitStatus(status int) Option {
+	return func(s *Sessions) {
+		s.exitStatus = &status
+	}
+}
+
+// Sessions tracks the outstanding sessions.
+// The zero value is not usable, create one with New.
+type Sessions struct {
+	mu       sync.Mutex
+	next     uint64
+	sessions map[uint64]io.Closer
+	closing  bool
+
+	message    string
+	exitStatus *int
+}
+
+// New creates an empty set of sessions.
+func New(opts ...Option) *Sessions {
+	s := &Sessions{
+		sessions: make(map[uint64]io.Closer),
+		message:  DefaultMessage,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Track tracks the session until the returned function is called, once the
+// session ended. Sessions tracked once the shutdown started are closed
+// right away, after the notification.
+func (s *Sessions) Track(session io.Closer) (untrack func()) {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		s.close(session)
+		return func() {}
+	}
+
+	s.next++
+	id := s.next
+	s.sessions[id] = session
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.sessions, id)
+		s.mu.Unlock()
+	}
+}
+
+// Active returns the number of outstanding sessions.
+func (s *Sessions) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// Closer returns a CloseFunc notifying and closing the outstanding sessions
+// concurrently. It fails if ctx is done first or if any session fails to close.
+func (s *Sessions) Closer() terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		s.mu.Lock()
+		s.closing = true
+		sessions := make([]io.Closer, 0, len(s.sessions))
+		for _, session := range s.sessions {
+			sessions = append(sessions, session)
+		}
+		s.mu.Unlock()
+
+		errs := make(chan error, len(sessions))
+		for _, session := range sessions {
+			go func(session io.Closer) {
+				errs <- s.close(session)
+			}(session)
+		}
+
+		failed := 0
+		for range sessions {
+			select {
+			case err := <-errs:
+				if err != nil {
+					failed++
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("sessions: %d of %d sessions failed to close", failed, len(sessions))
+		}
+
+		return nil
+	}
+}
+
+// close notifies the remote side of the session and closes it.
+func (s *Sessions) close(session io.Closer) error {
+	if s.message != "" {
+		var w io.Writer
+		if e, ok := session.(stderrer); ok {
+			w = e.Stderr()
+		} else if writer, ok := session.(io.Writer); ok {
+			w = writer
+		}
+		if w != nil {
+			io.WriteString(w, s.message)
+		}
+	}
+
+	if s.exitStatus != nil {
+		if e, ok := session.(exiter); ok {
+			return e.Exit(*s.exitStatus)
+		}
+		if r, ok := session.(requestSender); ok {
+			payload := make([]byte, 4)
+			binary.BigEndian.PutUint32(payload, uint32(*s.exitStatus))
+			r.SendRequest("exit-status", false, payload)
+		}
+	}
+
+	return session.Close()
+}
@@ -0,0 +1,112 @@
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// channel mimics an ssh.Channel.
+type channel struct {
+	stdout, stderr bytes.Buffer
+	requests       []string
+	payload        []byte
+	closed         bool
+	closeErr       error
+}
+
+func (c *channel) Stderr() io.ReadWriter { return &c.stderr }
+func (c *channel) Close() error          { c.closed = true; return c.closeErr }
+
+func (c *channel) SendRequest(name string, wantReply bool, payload []byte) (bool, error) {
+	c.requests = append(c.requests, name)
+	c.payload = payload
+	return true, nil
+}
+
+// session mimics a gliderlabs ssh.Session.
+type session struct {
+	bytes.Buffer
+	exitCode int
+	closed   bool
+}
+
+func (s *session) Exit(code int) error { s.exitCode = code; s.closed = true; return nil }
+func (s *session) Close() error        { s.closed = true; return nil }
+
+func TestCloser(t *testing.T) {
+	s := New(WithMessage("maintenance\n"), WithExitStatus(2))
+
+	ch := &channel{}
+	sess := &session{exitCode: -1}
+	ended := &channel{}
+
+	s.Track(ch)
+	s.Track(sess)
+	untrack := s.Track(ended)
+	untrack()
+
+	if s.Active() != 2 {
+		t.Fatalf("Expected 2 active sessions, got %d", s.Active())
+	}
+
+	if err := s.Closer()(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ch.closed || ch.stderr.String() != "maintenance\n" || ch.stdout.Len() != 0 {
+		t.Errorf("Expected the channel to be notified on stderr and closed, got %+v", ch)
+	}
+	if len(ch.requests) != 1 || ch.requests[0] != "exit-status" || !bytes.Equal(ch.payload, []byte{0, 0, 0, 2}) {
+		t.Errorf("Expected the exit status to be sent, got %v %v", ch.requests, ch.payload)
+	}
+	if !sess.closed || sess.String() != "maintenance\n" || sess.exitCode != 2 {
+		t.Errorf("Expected the session to be notified and exited, got %+v", sess)
+	}
+	if ended.closed {
+		t.Error("Untracked sessions shouldn't be closed")
+	}
+
+	late := &channel{}
+	s.Track(late)
+	if !late.closed || late.stderr.String() != "maintenance\n" {
+		t.Error("Sessions tracked during the shutdown should be closed right away")
+	}
+}
+
+func TestCloserFailure(t *testing.T) {
+	s := New(WithMessage(""))
+
+	ch := &channel{closeErr: errors.New("broken pipe")}
+	s.Track(ch)
+	s.Track(&channel{})
+
+	if err := s.Closer()(context.Background()); err == nil {
+		t.Error("Expected the failure to be reported")
+	}
+	if ch.stderr.Len() != 0 || len(ch.requests) != 0 {
+		t.Errorf("Expected no notification nor exit status, got %+v", ch)
+	}
+}
+
+// blocking is a session whose Close blocks.
+type blocking struct{ release chan struct{} }
+
+func (b blocking) Close() error { <-b.release; return nil }
+
+func TestCloserDeadline(t *testing.T) {
+	s := New()
+	b := blocking{release: make(chan struct{})}
+	defer close(b.release)
+	s.Track(b)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := s.Closer()(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+}
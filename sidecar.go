@@ -0,0 +1,7 @@
+package terminator
+
+// PhaseSidecarQuit closes the resources after everything else, finalizers
+// included. Like finalizers, it runs even when the termination is aborted.
+// WithSidecarQuit asks the sidecars to quit later still, once the result is
+// reported.
+const PhaseSidecarQuit Phase = 3000
@@ -0,0 +1,62 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// IstioQuitURL is the quit endpoint of the istio-proxy sidecar.
+const IstioQuitURL = "http://127.0.0.1:15020/quitquitquit"
+
+// sidecarQuitTimeout is how long every quit endpoint has to answer.
+const sidecarQuitTimeout = 5 * time.Second
+
+// WithSidecarQuit posts to the quit endpoints of the sidecar containers, such
+// as IstioQuitURL, as the final step of the termination, so that job-style
+// pods actually terminate once the application closed gracefully. The
+// sidecars quit after the summary file, the webhooks and the reporters, whose
+// traffic may go through them, and before Wait returns, even when the
+// termination is aborted. Every endpoint has 5 seconds to answer with a 2xx
+// status, and failures are logged.
+func WithSidecarQuit(urls ...string) Option {
+	return func(c *config) {
+		c.sidecarQuitURLs = append(c.sidecarQuitURLs, urls...)
+	}
+}
+
+// quitSidecars posts to every quit endpoint, logging failures.
+func (t *terminator) quitSidecars() {
+	for _, url := range t.config.sidecarQuitURLs {
+		if err := postQuit(url); err != nil {
+			t.config.logger.Printf("asking the sidecar %s to quit: %v", url, err)
+		}
+	}
+}
+
+// postQuit posts to the quit endpoint url.
+func postQuit(url string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sidecarQuitTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sidecar quit: unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
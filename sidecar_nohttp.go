@@ -0,0 +1,8 @@
+//go:build terminator_nohttp
+// +build terminator_nohttp
+
+package terminator
+
+// quitSidecars does nothing without net/http: WithSidecarQuit is not
+// available with the terminator_nohttp build tag.
+func (t *terminator) quitSidecars() {}
@@ -0,0 +1,90 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSidecarQuitRunsLast(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	appendOrder := func(step string) {
+		mu.Lock()
+		order = append(order, step)
+		mu.Unlock()
+	}
+
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/quitquitquit":
+			appendOrder("sidecar")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer sidecar.Close()
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithSidecarQuit(sidecar.URL+"/quitquitquit"),
+		WithReporter(ReporterFunc(func(result TerminationResult) error {
+			appendOrder("reporter")
+			return nil
+		})),
+	)
+	term.AddWithOptions("flush", func(ctx context.Context) error {
+		appendOrder("flush")
+		return nil
+	}, InPhase(PhaseFinalizer))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 3 || order[0] != "flush" || order[1] != "reporter" || order[2] != "sidecar" {
+		t.Errorf("Expected the sidecar to quit last, after the reporters, got %v", order)
+	}
+
+	result, _ := term.Result()
+	for _, data := range result.Result {
+		if strings.Contains(data.Name, "sidecar") {
+			t.Errorf("Expected the sidecar quit to stay out of the result, got %+v", data)
+		}
+	}
+}
+
+func TestSidecarQuitFailureLogged(t *testing.T) {
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sidecar.Close()
+
+	logger := make(lineLogger, 1)
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger),
+		WithSidecarQuit(sidecar.URL+"/quitquitquit"))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	select {
+	case line := <-logger:
+		if !strings.Contains(line, "503") {
+			t.Errorf("Expected the failed quit to be logged, got %q", line)
+		}
+	default:
+		t.Error("Expected the failed quit to be logged")
+	}
+}
@@ -0,0 +1,117 @@
+package terminator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+)
+
+// ErrUncatchableSignal is returned by ValidateSignals for signals that can never be delivered to the process.
+var ErrUncatchableSignal = errors.New("terminator: signal cannot be caught")
+
+// isUncatchable reports whether sig can never be caught by the process.
+func isUncatchable(sig os.Signal) bool {
+	for _, uncatchable := range uncatchableSignals {
+		if sig == uncatchable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateSignals returns an error wrapping ErrUncatchableSignal if any of the
+// signals can never be caught, such as os.Kill or SIGSTOP.
+func ValidateSignals(signals []os.Signal) error {
+	for _, sig := range signals {
+		if isUncatchable(sig) {
+			return fmt.Errorf("%w: %v", ErrUncatchableSignal, sig)
+		}
+	}
+
+	return nil
+}
+
+// catchableSignals returns the signals that can be caught, logging a warning for every dropped signal.
+// Uncatchable signals are a no-op: the operating system terminates the process without notifying it.
+func catchableSignals(signals []os.Signal, logger Logger) []os.Signal {
+	catchable := make([]os.Signal, 0, len(signals))
+
+	for _, sig := range signals {
+		if isUncatchable(sig) {
+			logger.Printf("ignoring signal %v: it cannot be caught, resources will not be closed when it is received", sig)
+			continue
+		}
+
+		catchable = append(catchable, sig)
+	}
+
+	return catchable
+}
+
+// signalHandler is a handler declared in the signal handler registry.
+type signalHandler struct {
+	owner   string
+	signals []os.Signal
+}
+
+// signalHandlers is the cooperative registry of the signal.Notify handlers of the process.
+var signalHandlers = struct {
+	sync.Mutex
+	handlers map[*signalHandler]struct{}
+}{handlers: make(map[*signalHandler]struct{})}
+
+// RegisterSignalHandler declares that owner handles the signals with
+// signal.Notify, so that terminators taking exclusive ownership of them warn
+// about it, see WithExclusiveSignals. Libraries subscribing to signals are
+// expected to call it cooperatively. The returned function removes the
+// declaration.
+func RegisterSignalHandler(owner string, signals ...os.Signal) (unregister func()) {
+	handler := &signalHandler{owner: owner, signals: signals}
+
+	signalHandlers.Lock()
+	signalHandlers.handlers[handler] = struct{}{}
+	signalHandlers.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signalHandlers.Lock()
+			delete(signalHandlers.handlers, handler)
+			signalHandlers.Unlock()
+		})
+	}
+}
+
+// signalOwners returns the owners of the declared handlers of sig, in no particular order.
+func signalOwners(sig os.Signal) []string {
+	signalHandlers.Lock()
+	defer signalHandlers.Unlock()
+
+	var owners []string
+	for handler := range signalHandlers.handlers {
+		for _, handled := range handler.signals {
+			if handled == sig {
+				owners = append(owners, handler.owner)
+				break
+			}
+		}
+	}
+
+	return owners
+}
+
+// resetSignals resets the handlers of the signals, logging a warning for
+// every signal that had other declared handlers.
+func resetSignals(signals []os.Signal, logger Logger) {
+	for _, sig := range signals {
+		if owners := signalOwners(sig); len(owners) > 0 {
+			logger.Printf("taking exclusive ownership of signal %v: handlers of %s will no longer receive it", sig, strings.Join(owners, ", "))
+		}
+	}
+
+	signal.Reset(signals...)
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package terminator
+
+import "os"
+
+// uncatchableSignals are the signals the process can never be notified of.
+var uncatchableSignals = []os.Signal{os.Kill}
+
+// hangupSignals are the signals sent when the terminal of the process closes,
+// delivered as syscall.SIGTERM on Windows.
+var hangupSignals []os.Signal
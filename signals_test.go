@@ -0,0 +1,70 @@
+package terminator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestValidateSignals(t *testing.T) {
+	if err := ValidateSignals([]os.Signal{os.Interrupt}); err != nil {
+		t.Errorf("os.Interrupt should be valid, got %v", err)
+	}
+
+	err := ValidateSignals([]os.Signal{os.Interrupt, os.Kill})
+	if !errors.Is(err, ErrUncatchableSignal) {
+		t.Errorf("os.Kill should be reported as uncatchable, got %v", err)
+	}
+}
+
+func TestUncatchableSignalWarning(t *testing.T) {
+	logger := &recordingLogger{}
+	NewTerminator([]os.Signal{os.Interrupt, os.Kill}, WithLogger(logger))
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], os.Kill.String()) {
+		t.Errorf("Expected a warning about os.Kill, got %q", logger.lines)
+	}
+}
+
+func TestNoSignals(t *testing.T) {
+	term := NewTerminator(nil).(*terminator)
+
+	if len(term.signals) != 0 || term.unregisterSignals != nil {
+		t.Errorf("Expected no signal to be subscribed, got %v", term.signals)
+	}
+}
+
+func TestRegisterSignalHandler(t *testing.T) {
+	unregister := RegisterSignalHandler("library", os.Interrupt)
+
+	if owners := signalOwners(os.Interrupt); !containsString(owners, "library") {
+		t.Errorf("Expected library to handle os.Interrupt, got %v", owners)
+	}
+
+	unregister()
+	unregister()
+
+	if owners := signalOwners(os.Interrupt); containsString(owners, "library") {
+		t.Errorf("Expected library to be unregistered, got %v", owners)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,15 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package terminator
+
+import (
+	"os"
+	"syscall"
+)
+
+// uncatchableSignals are the signals the process can never be notified of.
+var uncatchableSignals = []os.Signal{os.Kill, syscall.SIGSTOP}
+
+// hangupSignals are the signals sent when the terminal of the process closes.
+var hangupSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,90 @@
+package terminator
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep pauses for d or until ctx is done, whichever comes first, and
+// returns ctx.Err() in the latter case. With the context of a goroutine
+// started with Go, loops waiting between iterations stop as soon as the
+// termination reaches them:
+//
+//	for {
+//		poll(ctx)
+//		if err := terminator.Sleep(ctx, time.Minute); err != nil {
+//			return err
+//		}
+//	}
+func Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Backoff sleeps for exponentially growing delays between the retries of a
+// failing operation, returning early once ctx is done, see Sleep.
+// The zero value waits 100ms first, doubling up to 30s.
+type Backoff struct {
+
+	// Initial delay, 100ms if zero
+	Initial time.Duration
+
+	// Max delay, 30s if zero
+	Max time.Duration
+
+	// Factor by which the delay grows after every wait, 2 if zero
+	Factor float64
+
+	next time.Duration
+}
+
+// Wait sleeps for the next delay, or until ctx is done, in which case it
+// returns ctx.Err().
+func (b *Backoff) Wait(ctx context.Context) error {
+	return Sleep(ctx, b.Next())
+}
+
+// Next returns the next delay and grows the following one.
+func (b *Backoff) Next() time.Duration {
+	initial, max, factor := b.Initial, b.Max, b.Factor
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	if factor <= 0 {
+		factor = 2
+	}
+
+	delay := b.next
+	if delay <= 0 {
+		delay = initial
+	}
+	if delay > max {
+		delay = max
+	}
+
+	b.next = time.Duration(float64(delay) * factor)
+	if b.next > max || b.next <= 0 {
+		b.next = max
+	}
+
+	return delay
+}
+
+// Reset restarts the delays from Initial, once the operation succeeded.
+func (b *Backoff) Reset() {
+	b.next = 0
+}
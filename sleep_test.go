@@ -0,0 +1,66 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSleep(t *testing.T) {
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Expected the sleep to complete, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSleepStopsOnShutdown(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	stopped := make(chan error, 1)
+	term.Go("poller", func(ctx context.Context) error {
+		err := Sleep(ctx, time.Hour)
+		stopped <- err
+		return err
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The sleeping goroutine should stop with the termination")
+	}
+	if err := <-stopped; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Factor: 3}
+
+	expected := []time.Duration{10, 30, 50, 50}
+	for i, delay := range expected {
+		if next := b.Next(); next != delay*time.Millisecond {
+			t.Errorf("Delay %d: expected %v, got %v", i, delay*time.Millisecond, next)
+		}
+	}
+
+	b.Reset()
+	if next := b.Next(); next != 10*time.Millisecond {
+		t.Errorf("Expected Reset to restart from the initial delay, got %v", next)
+	}
+
+	var zero Backoff
+	if next := zero.Next(); next != 100*time.Millisecond {
+		t.Errorf("Expected a default initial delay of 100ms, got %v", next)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected Wait to return early, got %v", err)
+	}
+}
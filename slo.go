@@ -0,0 +1,132 @@
+package terminator
+
+import (
+	"sort"
+	"time"
+)
+
+// reportedSlowest is the number of slowest resources reported in the SLOReport.
+const reportedSlowest = 3
+
+// WithSLO sets the objective for the duration of the termination, from the
+// termination signal until all the resources are closed. The result reports
+// how the termination compared to it, see SLOReport.
+func WithSLO(d time.Duration) Option {
+	return func(c *config) {
+		c.slo = d
+	}
+}
+
+// WithPhaseBudget sets the time the resources of the phase have together
+// to close. Overruns are reported in the SLOReport of the result, they do
+// not cut the phase short.
+func WithPhaseBudget(phase Phase, d time.Duration) Option {
+	return func(c *config) {
+		if c.phaseBudgets == nil {
+			c.phaseBudgets = make(map[Phase]time.Duration)
+		}
+		c.phaseBudgets[phase] = d
+	}
+}
+
+// SLOReport compares the duration of the termination with its objectives,
+// to track the shutdown health over time.
+type SLOReport struct {
+
+	// Objective set with WithSLO, zero if none
+	Target time.Duration
+
+	// Time from the termination signal until all the resources were closed
+	Duration time.Duration
+
+	// WithinSLO is set when the termination met its objective, if any,
+	// and no phase overran its budget
+	WithinSLO bool
+
+	// Duration of every phase, in execution order
+	Phases []PhaseReport
+
+	// Result data of the 3 slowest resources, slowest first, see SlowestN
+	Slowest []TerminationResultData
+}
+
+// PhaseReport is the duration of a phase compared with its budget.
+type PhaseReport struct {
+
+	// Phase of the resources
+	Phase Phase
+
+	// Budget set with WithPhaseBudget, zero if none
+	Budget time.Duration
+
+	// Time taken to close the resources of the phase
+	Duration time.Duration
+
+	// Overrun is the time the phase took beyond its budget, zero if within it
+	Overrun time.Duration
+}
+
+// sloReport compares the duration of the termination and of its phases with their objectives.
+func (c *config) sloReport(duration time.Duration, phases []PhaseReport, slowest []TerminationResultData) SLOReport {
+	report := SLOReport{
+		Target:    c.slo,
+		Duration:  duration,
+		WithinSLO: c.slo <= 0 || duration <= c.slo,
+		Phases:    phases,
+		Slowest:   slowest,
+	}
+
+	for index := range report.Phases {
+		phase := &report.Phases[index]
+		phase.Budget = c.phaseBudgets[phase.Phase]
+
+		if phase.Budget > 0 && phase.Duration > phase.Budget {
+			phase.Overrun = phase.Duration - phase.Budget
+			report.WithinSLO = false
+		}
+	}
+
+	return report
+}
+
+// SlowestN returns the result data of the n slowest resources, slowest first,
+// to tell what made the termination slow. With WithResultSummary, they are
+// taken from the slowest resources retained by the Summary.
+func (r TerminationResult) SlowestN(n int) []TerminationResultData {
+	candidates := r.Result
+	if r.Summary != nil {
+		candidates = r.Summary.Slowest
+	}
+
+	slowest := append([]TerminationResultData(nil), candidates...)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].Duration > slowest[j].Duration
+	})
+
+	if n < 0 {
+		n = 0
+	}
+	if n < len(slowest) {
+		slowest = slowest[:n]
+	}
+
+	return slowest
+}
+
+// budgetOverrunLocked returns the largest overrun of the shutdown budget by
+// the resources closed at closedAt and of the objective by the termination
+// that took duration. It must be called with t.mu held.
+func (t *terminator) budgetOverrunLocked(closedAt time.Time, duration time.Duration) time.Duration {
+	var overrun time.Duration
+	if !t.budgetDeadline.IsZero() {
+		overrun = closedAt.Sub(t.budgetDeadline)
+	}
+	if slo := t.config.slo; slo > 0 && duration-slo > overrun {
+		overrun = duration - slo
+	}
+	if overrun < 0 {
+		return 0
+	}
+
+	return overrun
+}
@@ -0,0 +1,159 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSLOReport(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithSLO(time.Second),
+		WithPhaseBudget(PhaseServer, 10*time.Millisecond),
+		WithPhaseBudget(DefaultPhase, time.Second),
+	)
+
+	sleep := func(d time.Duration) CloseFunc {
+		return func(ctx context.Context) error {
+			time.Sleep(d)
+			return nil
+		}
+	}
+
+	term.AddWithOptions("server", sleep(50*time.Millisecond), InPhase(PhaseServer))
+	term.Add("db", sleep(0))
+	term.AddWithOptions("telemetry", sleep(0), InPhase(PhaseTelemetry))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	report := result.SLO
+
+	if report.Target != time.Second || report.Duration < 50*time.Millisecond || report.Duration > time.Second {
+		t.Errorf("Unexpected duration %v for target %v", report.Duration, report.Target)
+	}
+	if report.WithinSLO {
+		t.Error("Expected the server phase overrun to break the SLO")
+	}
+
+	if len(report.Phases) != 3 {
+		t.Fatalf("Expected 3 phases, got %+v", report.Phases)
+	}
+
+	server, db, telemetry := report.Phases[0], report.Phases[1], report.Phases[2]
+	if server.Phase != PhaseServer || server.Budget != 10*time.Millisecond || server.Overrun < 40*time.Millisecond {
+		t.Errorf("Expected the server phase to overrun its budget, got %+v", server)
+	}
+	if db.Phase != DefaultPhase || db.Overrun != 0 {
+		t.Errorf("Expected the default phase within its budget, got %+v", db)
+	}
+	if telemetry.Phase != PhaseTelemetry || telemetry.Budget != 0 || telemetry.Overrun != 0 {
+		t.Errorf("Expected the telemetry phase without budget, got %+v", telemetry)
+	}
+}
+
+func TestSLOReportWithinSLO(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithSLO(time.Second))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if !result.SLO.WithinSLO {
+		t.Errorf("Expected the termination to be within its SLO, got %+v", result.SLO)
+	}
+	if result.ExceededBudget || result.BudgetOverrun != 0 {
+		t.Errorf("Expected no overrun, got %v", result.BudgetOverrun)
+	}
+}
+
+func TestSlowestN(t *testing.T) {
+	result := TerminationResult{Result: []TerminationResultData{
+		{Name: "cache", Duration: 20 * time.Millisecond},
+		{Name: "server", Duration: 300 * time.Millisecond},
+		{Name: "queue", Duration: 5 * time.Millisecond},
+		{Name: "db", Duration: 90 * time.Millisecond},
+	}}
+
+	slowest := result.SlowestN(2)
+	if len(slowest) != 2 || slowest[0].Name != "server" || slowest[1].Name != "db" {
+		t.Errorf("Expected server and db, got %+v", slowest)
+	}
+	if len(result.SlowestN(10)) != 4 || len(result.SlowestN(-1)) != 0 {
+		t.Error("Expected n to be bounded by the number of resources")
+	}
+	if result.Result[0].Name != "cache" {
+		t.Error("SlowestN shouldn't reorder the result")
+	}
+}
+
+func TestSLOReportSlowest(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	for _, delay := range []int{1, 40, 2, 30, 3, 20} {
+		delay := time.Duration(delay) * time.Millisecond
+		term.Add(delay.String(), func(ctx context.Context) error {
+			time.Sleep(delay)
+			return nil
+		})
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	slowest := result.SLO.Slowest
+	if len(slowest) != 3 || slowest[0].Name != "40ms" || slowest[1].Name != "30ms" || slowest[2].Name != "20ms" {
+		t.Errorf("Expected the 3 slowest resources in the report, got %+v", slowest)
+	}
+}
+
+func TestBudgetOverrun(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0),
+		WithShutdownBudget(20*time.Millisecond),
+		WithSLO(time.Second),
+	)
+
+	term.AddWithOptions("commit", func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}, WithDetachedContext(time.Second))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if !result.ExceededBudget || result.BudgetOverrun < 70*time.Millisecond || result.BudgetOverrun > time.Second {
+		t.Errorf("Expected the detached closer to overrun the budget, got %v", result.BudgetOverrun)
+	}
+}
+
+func TestBudgetOverrunSLO(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithSLO(10*time.Millisecond))
+
+	term.Add("db", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if !result.ExceededBudget || result.BudgetOverrun < 40*time.Millisecond {
+		t.Errorf("Expected the termination to overrun its objective, got %v", result.BudgetOverrun)
+	}
+}
@@ -0,0 +1,71 @@
+package terminator
+
+import "strconv"
+
+// Standard phases, for teams to converge on a common shutdown shape. They
+// are closed in this order, around the phases of the service presets:
+//
+//	PhaseIngress    stop accepting work: servers, listeners, consumers
+//	PhaseWorkers    wait for the in-flight work to complete
+//	DefaultPhase    application resources registered without a phase
+//	PhaseClients    close the clients of other services and brokers
+//	PhaseStorage    close databases, caches and files
+//	PhaseTelemetry  flush telemetry and logs
+//
+// They share their values with the presets: PhaseIngress is PhaseServer and
+// PhaseIntake, PhaseWorkers is PhaseDrain and PhaseClients is PhaseBroker.
+const (
+
+	// PhaseIngress stops accepting work, such as servers, listeners and consumers.
+	PhaseIngress = PhaseServer
+
+	// PhaseWorkers waits for the in-flight work to complete.
+	PhaseWorkers = PhaseDrain
+
+	// PhaseClients closes the clients of other services and brokers.
+	PhaseClients = PhaseBroker
+
+	// PhaseStorage closes databases, caches and files, once nothing uses them anymore.
+	PhaseStorage Phase = 500
+)
+
+// phaseNames are the names of the well-known phases.
+var phaseNames = map[Phase]string{
+	PhaseAnnounce:    "announce",
+	PhaseBackground:  "background",
+	PhaseReadiness:   "readiness",
+	PhasePreStop:     "pre-stop",
+	PhaseIngress:     "ingress",
+	PhaseWorkers:     "workers",
+	PhaseCommit:      "commit",
+	DefaultPhase:     "default",
+	PhaseClients:     "clients",
+	PhaseStorage:     "storage",
+	PhaseTelemetry:   "telemetry",
+	PhaseFinalizer:   "finalizer",
+	PhaseSidecarQuit: "sidecar-quit",
+}
+
+// String returns the name of the well-known phases, and the number of the others.
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+
+	return "phase(" + strconv.Itoa(int(p)) + ")"
+}
+
+// IsStandard reports whether the phase is one of the well-known phases.
+func (p Phase) IsStandard() bool {
+	_, ok := phaseNames[p]
+	return ok
+}
+
+// WithStandardPhases logs a warning for every resource registered in a
+// phase other than the well-known ones, that is the standard phases and
+// those of the service presets, so teams converge on a common shutdown shape.
+func WithStandardPhases() Option {
+	return func(c *config) {
+		c.standardPhases = true
+	}
+}
@@ -0,0 +1,42 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStandardPhaseOrder(t *testing.T) {
+	order := []Phase{PhaseIngress, PhaseWorkers, DefaultPhase, PhaseClients, PhaseStorage, PhaseTelemetry}
+
+	for i := 1; i < len(order); i++ {
+		if order[i-1] >= order[i] {
+			t.Errorf("Expected %v to be closed before %v", order[i-1], order[i])
+		}
+	}
+}
+
+func TestPhaseString(t *testing.T) {
+	if PhaseStorage.String() != "storage" || PhaseServer.String() != "ingress" {
+		t.Errorf("Unexpected names %v and %v", PhaseStorage, PhaseServer)
+	}
+
+	if Phase(42).String() != "phase(42)" || Phase(42).IsStandard() {
+		t.Errorf("Unexpected name %v for a custom phase", Phase(42))
+	}
+}
+
+func TestWithStandardPhases(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithStandardPhases())
+	noop := func(ctx context.Context) error { return nil }
+
+	term.Add("app", noop)
+	term.AddWithOptions("db", noop, InPhase(PhaseStorage))
+	term.AddWithOptions("custom", noop, InPhase(Phase(42)))
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "custom") {
+		t.Errorf("Expected a single warning about the custom phase, got %q", logger.lines)
+	}
+}
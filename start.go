@@ -0,0 +1,108 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Component is a part of the application with lifecycle hooks, started by
+// Start and stopped by the termination.
+type Component struct {
+	Name string
+
+	// OnStart starts the component, if not nil.
+	OnStart func(context.Context) error
+
+	// OnStop stops the component, registered as its closer once it started,
+	// as configured by Options.
+	OnStop  CloseFunc
+	Options []CloserOption
+}
+
+// StartError is returned by Start when a component failed to start.
+type StartError struct {
+	// Name is the name of the component that failed to start, and Err its error.
+	Name string
+	Err  error
+
+	// Rollback holds the results of the components stopped by the rollback,
+	// in the order they were stopped.
+	Rollback []TerminationResultData
+}
+
+// Error implements error.
+func (e *StartError) Error() string {
+	var failed []string
+	for _, termData := range e.Rollback {
+		if termData.Error != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", termData.Name, termData.Error))
+		}
+	}
+
+	msg := fmt.Sprintf("terminator: starting %s: %v", e.Name, e.Err)
+	if len(failed) > 0 {
+		msg += fmt.Sprintf("; rollback: %d of %d components failed to stop: %s", len(failed), len(e.Rollback), strings.Join(failed, "; "))
+	}
+
+	return msg
+}
+
+// Unwrap returns the error of the component that failed to start.
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
+// Start starts the components one after another with ctx, registering the
+// OnStop hook of every component started as its closer. If a component
+// fails to start, the components already started by this call are stopped
+// in reverse order, with the timeouts of their closers, and removed from the
+// closers stack, like a transaction rolled back, and a *StartError is
+// returned. A component whose stop hook is rejected, with ErrSealed, is
+// stopped right away and fails to start. Components started when the
+// termination begins are left for the
+// termination to stop, and reported with ErrShuttingDown in the rollback.
+func (t *terminator) Start(ctx context.Context, components ...Component) error {
+	var started []*Handle
+
+	for _, component := range components {
+		var err error
+		if component.OnStart != nil {
+			err = component.OnStart(ctx)
+		}
+
+		if err == nil && component.OnStop != nil {
+			var handle *Handle
+			if handle, err = t.AddWithOptions(component.Name, component.OnStop, component.Options...); err == nil {
+				started = append(started, handle)
+			} else {
+				component.OnStop(context.Background())
+			}
+		}
+
+		if err != nil {
+			return &StartError{Name: component.Name, Err: err, Rollback: t.rollback(started)}
+		}
+	}
+
+	return nil
+}
+
+// rollback stops the started components in reverse order.
+func (t *terminator) rollback(started []*Handle) []TerminationResultData {
+	results := make([]TerminationResultData, 0, len(started))
+
+	for index := len(started) - 1; index >= 0; index-- {
+		closer := started[index].closer
+
+		removed, err := t.removeID(closer.id)
+		if err != nil {
+			results = append(results, TerminationResultData{Name: closer.Name, Error: err, Kind: ClassifyError(err), Status: FAILED})
+			continue
+		}
+
+		results = append(results, <-t.closeStack(context.Background(), &removed, false))
+	}
+
+	return results
+}
@@ -0,0 +1,63 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestStartRollback(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	var stopped []string
+	component := func(name string, startErr, stopErr error) Component {
+		return Component{
+			Name:    name,
+			OnStart: func(ctx context.Context) error { return startErr },
+			OnStop: func(ctx context.Context) error {
+				stopped = append(stopped, name)
+				return stopErr
+			},
+		}
+	}
+
+	errStart := errors.New("cannot connect")
+	errStop := errors.New("cannot flush")
+	err := term.Start(context.Background(),
+		component("db", nil, nil),
+		component("cache", nil, errStop),
+		component("broker", errStart, nil),
+		component("server", nil, nil),
+	)
+
+	var startErr *StartError
+	if !errors.As(err, &startErr) || startErr.Name != "broker" || !errors.Is(err, errStart) {
+		t.Fatalf("Expected the broker to fail to start, got %v", err)
+	}
+	if want := []string{"cache", "db"}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("Expected the started components to be stopped in reverse order, got %v", stopped)
+	}
+	if len(startErr.Rollback) != 2 || startErr.Rollback[0].Error != errStop || startErr.Rollback[1].Status != SUCCESS {
+		t.Errorf("Expected the results of the rollback, got %+v", startErr.Rollback)
+	}
+	if registered := term.Status().Registered; registered != 0 {
+		t.Errorf("Expected the stopped components to be removed, got %d registered", registered)
+	}
+}
+
+func TestStart(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	err := term.Start(context.Background(),
+		Component{Name: "db", OnStop: func(ctx context.Context) error { return nil }},
+		Component{Name: "warmup", OnStart: func(ctx context.Context) error { return nil }},
+	)
+	if err != nil {
+		t.Fatalf("Expected the components to start, got %v", err)
+	}
+	if registered := term.Status().Registered; registered != 1 {
+		t.Errorf("Expected the stop hook to be registered, got %d registered", registered)
+	}
+}
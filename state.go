@@ -0,0 +1,84 @@
+package terminator
+
+import "time"
+
+// State is a stage of the terminator lifecycle.
+type State string
+
+const (
+
+	// StateIdle is the state until the termination signal is received.
+	StateIdle State = "IDLE"
+
+	// StateDraining is the state between the termination signal and the
+	// closing of the resources, while registrations settle.
+	StateDraining State = "DRAINING"
+
+	// StateClosing is the state while the resources are being closed.
+	StateClosing State = "CLOSING"
+
+	// StateFinalizing is the state while the callback runs.
+	// An aborted termination goes through it as well.
+	StateFinalizing State = "FINALIZING"
+
+	// StateDone is the state once the termination process completed.
+	StateDone State = "DONE"
+
+	// StateAborted is the state once the termination process was abandoned,
+	// either by Abort or by a signal received before Ready with WithNotReadyExit.
+	StateAborted State = "ABORTED"
+)
+
+// transitions lists the states reachable from every state.
+var transitions = map[State][]State{
+	StateIdle:       {StateDraining},
+	StateDraining:   {StateClosing, StateAborted},
+	StateClosing:    {StateFinalizing, StateAborted},
+	StateFinalizing: {StateDone, StateAborted},
+}
+
+// IsTerminal reports whether the lifecycle ends in the state.
+func (s State) IsTerminal() bool {
+	return s == StateDone || s == StateAborted
+}
+
+// canTransition reports whether the lifecycle can move from one state to another.
+func canTransition(from, to State) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// State returns the current state of the terminator lifecycle.
+func (t *terminator) State() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.state
+}
+
+// transition moves the lifecycle to the given state and emits an
+// EventStateChanged. It reports false, changing nothing, if the transition
+// is not allowed from the current state.
+func (t *terminator) transition(to State) bool {
+	t.mu.Lock()
+	from := t.state
+	if !canTransition(from, to) {
+		t.mu.Unlock()
+		return false
+	}
+	t.state = to
+	if from == StateIdle {
+		t.shutdownAt = time.Now()
+		close(t.shutdownChan)
+	}
+	t.mu.Unlock()
+
+	t.emit(Event{Kind: EventStateChanged, From: from, To: to})
+
+	return true
+}
@@ -0,0 +1,86 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStateTransitions(t *testing.T) {
+	var mu sync.Mutex
+	changes := []State{}
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithEventHandler(func(e Event) {
+		if e.Kind != EventStateChanged {
+			return
+		}
+
+		mu.Lock()
+		changes = append(changes, e.To)
+		mu.Unlock()
+	}))
+
+	if state := term.State(); state != StateIdle {
+		t.Errorf("Expected %s, got %s", StateIdle, state)
+	}
+
+	closing := make(chan State, 1)
+	term.Add("app1", func(ctx context.Context) error {
+		closing <- term.State()
+		return nil
+	})
+
+	finalizing := make(chan State, 1)
+	term.SetCallback(func(result TerminationResult) {
+		finalizing <- term.State()
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if state := <-closing; state != StateClosing {
+		t.Errorf("Closers should run in %s, got %s", StateClosing, state)
+	}
+
+	if state := <-finalizing; state != StateFinalizing {
+		t.Errorf("Callback should run in %s, got %s", StateFinalizing, state)
+	}
+
+	if state := term.State(); state != StateDone {
+		t.Errorf("Expected %s, got %s", StateDone, state)
+	}
+
+	expected := []State{StateDraining, StateClosing, StateFinalizing, StateDone}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(changes) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, changes)
+		return
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, changes)
+			return
+		}
+	}
+}
+
+func TestInvalidTransition(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}).(*terminator)
+
+	if term.transition(StateDone) {
+		t.Error("Idle terminator shouldn't move to Done")
+	}
+
+	if term.State() != StateIdle {
+		t.Error("State shouldn't change on an invalid transition")
+	}
+}
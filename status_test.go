@@ -0,0 +1,61 @@
+package terminator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTerminationStatusText(t *testing.T) {
+	for _, status := range TerminationStatuses() {
+		text, err := status.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", status, err)
+		}
+		if string(text) != status.String() {
+			t.Errorf("Expected %q, got %q", status.String(), text)
+		}
+
+		var parsed TerminationStatus
+		if err := parsed.UnmarshalText(text); err != nil || parsed != status {
+			t.Errorf("Expected %v, got %v (%v)", status, parsed, err)
+		}
+	}
+
+	var zero TerminationStatus
+	if _, err := zero.MarshalText(); err == nil {
+		t.Error("The zero status shouldn't be marshaled")
+	}
+	if zero.String() != "TerminationStatus(0)" {
+		t.Errorf("Unexpected name %q", zero.String())
+	}
+	if err := zero.UnmarshalText([]byte("TIMEOUT")); err == nil {
+		t.Error("Expected an error for an unknown status")
+	}
+
+	data, err := json.Marshal(map[string]TerminationStatus{"db": FAILED})
+	if err != nil || string(data) != `{"db":"FAILED"}` {
+		t.Errorf("Unexpected JSON %s (%v)", data, err)
+	}
+}
+
+func TestTerminationStatusIsTerminal(t *testing.T) {
+	for _, status := range TerminationStatuses() {
+		var terminal bool
+		switch status {
+		case SUCCESS, FAILED, ABORTED, SKIPPED:
+			terminal = true
+		case PENDING:
+			terminal = false
+		default:
+			t.Fatalf("Status %v is not handled", status)
+		}
+
+		if status.IsTerminal() != terminal {
+			t.Errorf("%v: expected terminal %v", status, terminal)
+		}
+	}
+
+	if TerminationStatus(0).IsTerminal() {
+		t.Error("The zero status shouldn't be terminal")
+	}
+}
@@ -0,0 +1,72 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// StepFunc closes a resource cooperatively, one chunk of work per call. It
+// returns true once the resource is closed. Each call should be short, as
+// the deadline is only checked between calls.
+type StepFunc func(context.Context) (done bool, err error)
+
+// closerStateKey is the context key of the closerState.
+type closerStateKey struct{}
+
+// closerState is shared between a running closer and the terminator through the closer's context.
+type closerState struct {
+	steps int64
+
+	// progress holds the bits of the float64 progress reported with SetProgress.
+	progress uint64
+
+	name string
+	term *terminator
+
+	// mu guards subResults, reported with ReportSubResult.
+	mu         sync.Mutex
+	subResults []TerminationResultData
+}
+
+// withCloserState returns a context carrying a new closerState for the named closer of t.
+func withCloserState(ctx context.Context, name string, t *terminator) (context.Context, *closerState) {
+	state := &closerState{name: name, term: t}
+	return context.WithValue(ctx, closerStateKey{}, state), state
+}
+
+// closerStateFrom returns the closerState of the closer running with ctx, if any.
+func closerStateFrom(ctx context.Context) *closerState {
+	state, _ := ctx.Value(closerStateKey{}).(*closerState)
+	return state
+}
+
+// AddSteps registers a resource closed by calling step repeatedly until it
+// reports done, fails or the closer's deadline is reached. The number of
+// steps run is reported in the result. It returns the handle of the
+// resource and the error of the registration, as Add does.
+func (t *terminator) AddSteps(name string, step StepFunc, opts ...CloserOption) (*Handle, error) {
+	return t.AddWithOptions(name, stepCloser(step), opts...)
+}
+
+// stepCloser adapts a StepFunc to a CloseFunc.
+func stepCloser(step StepFunc) CloseFunc {
+	return func(ctx context.Context) error {
+		state := closerStateFrom(ctx)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			done, err := step(ctx)
+			if state != nil {
+				atomic.AddInt64(&state.steps, 1)
+			}
+
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAddSteps(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	chunks := 5
+	term.AddSteps("cache", func(ctx context.Context) (bool, error) {
+		chunks--
+		return chunks == 0, nil
+	})
+
+	slow := 0
+	term.AddSteps("slow", func(ctx context.Context) (bool, error) {
+		slow++
+		time.Sleep(20 * time.Millisecond)
+		return false, nil
+	}, WithCloserTimeout(100*time.Millisecond))
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if len(result.Result) != 2 {
+		t.Errorf("Unexpected result: %+v", result)
+		return
+	}
+
+	if data := result.Result[0]; data.Status != FAILED || data.Steps == 0 || data.Steps >= 10 {
+		t.Errorf("slow should be cut off by its deadline, got %+v", data)
+	}
+
+	if data := result.Result[1]; data.Status != SUCCESS || data.Steps != 5 {
+		t.Errorf("cache should complete in 5 steps, got %+v", data)
+	}
+}
@@ -0,0 +1,81 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReportSubResult adds data to the SubResults of the closer running with
+// ctx, for composite closers to report their internal breakdown instead of
+// flattening it into a single error. It is meant to be called from within a
+// CloseFunc and does nothing when ctx does not belong to a closer. Results
+// reported after the closer returned or timed out are dropped.
+func ReportSubResult(ctx context.Context, data TerminationResultData) {
+	state := closerStateFrom(ctx)
+	if state == nil {
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.subResults = append(state.subResults, data)
+}
+
+// loadSubResults returns the sub-results reported so far.
+func (state *closerState) loadSubResults() []TerminationResultData {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if len(state.subResults) == 0 {
+		return nil
+	}
+
+	subResults := make([]TerminationResultData, len(state.subResults))
+	copy(subResults, state.subResults)
+
+	return subResults
+}
+
+// Part is a named part of a composite closer, see Composite.
+type Part struct {
+	Name  string
+	Close CloseFunc
+}
+
+// Composite returns a CloseFunc closing the parts one after another with the
+// closer's context, reporting each of them as a sub-result. Every part is
+// closed even if a previous one failed, and the returned error lists the
+// failed parts.
+func Composite(parts ...Part) CloseFunc {
+	return func(ctx context.Context) error {
+		var failed []string
+
+		for _, part := range parts {
+			start := time.Now()
+			err := part.Close(ctx)
+
+			data := TerminationResultData{
+				Name:     part.Name,
+				Error:    err,
+				Kind:     ClassifyError(err),
+				Status:   SUCCESS,
+				Duration: time.Since(start),
+			}
+			if err != nil {
+				data.Status = FAILED
+				failed = append(failed, fmt.Sprintf("%s: %v", part.Name, err))
+			}
+
+			ReportSubResult(ctx, data)
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("terminator: %d of %d parts failed: %s", len(failed), len(parts), strings.Join(failed, "; "))
+		}
+
+		return nil
+	}
+}
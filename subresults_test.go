@@ -0,0 +1,50 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComposite(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	var closed []string
+	part := func(name string, err error) Part {
+		return Part{Name: name, Close: func(ctx context.Context) error {
+			closed = append(closed, name)
+			return err
+		}}
+	}
+
+	term.Add("escalation", Composite(
+		part("graceful", errors.New("refused")),
+		part("forced", nil),
+	))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	if len(closed) != 2 {
+		t.Errorf("Expected every part to be closed, got %v", closed)
+	}
+
+	result, _ := term.Result()
+	termData := result.Result[0]
+	if termData.Status != FAILED || !strings.Contains(termData.Error.Error(), "graceful: refused") {
+		t.Errorf("Expected the failed part in the error, got %v", termData.Error)
+	}
+
+	if len(termData.SubResults) != 2 || termData.SubResults[0].Status != FAILED || termData.SubResults[1].Status != SUCCESS {
+		t.Errorf("Expected the parts as sub-results, got %+v", termData.SubResults)
+	}
+}
+
+func TestReportSubResultOutsideCloser(t *testing.T) {
+	ReportSubResult(context.Background(), TerminationResultData{Name: "ignored"})
+}
@@ -0,0 +1,100 @@
+package terminator
+
+import "sort"
+
+// WithResultSummary aggregates the results instead of retaining the result
+// data of every resource, keeping the memory used by the termination
+// bounded for stacks of tens of thousands of resources. The Result of the
+// TerminationResult then only holds the resources that failed or timed out,
+// and its Summary reports the number of resources by status along with the
+// slowest ones, up to slowest of them.
+func WithResultSummary(slowest int) Option {
+	return func(c *config) {
+		c.resultSummary = true
+		c.summarySlowest = slowest
+	}
+}
+
+// ResultSummary aggregates the results of the termination, see WithResultSummary.
+type ResultSummary struct {
+
+	// Number of resources closed, whatever their status
+	Total int
+
+	// Number of resources by termination status
+	Counts map[TerminationStatus]int
+
+	// Result data of the slowest resources, slowest first
+	Slowest []TerminationResultData
+
+	// limit is the number of slowest resources retained.
+	limit int
+}
+
+// newResultSummary returns an empty summary retaining the limit slowest resources.
+func newResultSummary(limit int) *ResultSummary {
+	return &ResultSummary{
+		Counts: make(map[TerminationStatus]int),
+		limit:  limit,
+	}
+}
+
+// add accounts for the result data of a resource.
+func (s *ResultSummary) add(termData TerminationResultData) {
+	s.Total++
+	s.Counts[termData.Status]++
+
+	if s.limit <= 0 {
+		return
+	}
+
+	index := sort.Search(len(s.Slowest), func(i int) bool {
+		return s.Slowest[i].Duration < termData.Duration
+	})
+	if index >= s.limit {
+		return
+	}
+
+	if len(s.Slowest) < s.limit {
+		s.Slowest = append(s.Slowest, TerminationResultData{})
+	}
+	copy(s.Slowest[index+1:], s.Slowest[index:])
+	s.Slowest[index] = termData
+}
+
+// clone returns a copy of the summary, which keeps being updated while the
+// termination runs.
+func (s *ResultSummary) clone() *ResultSummary {
+	if s == nil {
+		return nil
+	}
+
+	clone := *s
+	clone.Counts = make(map[TerminationStatus]int, len(s.Counts))
+	for status, count := range s.Counts {
+		clone.Counts[status] = count
+	}
+	clone.Slowest = append([]TerminationResultData(nil), s.Slowest...)
+
+	return &clone
+}
+
+// record accounts for the result data of the resource at index in execution
+// order. It must be called with t.mu held.
+func (t *terminator) record(result *TerminationResult, index int, termData TerminationResultData) {
+	result.closed++
+	if termData.Error != nil {
+		result.FailedOrTimeoutCount++
+	}
+
+	if result.Summary == nil {
+		result.Result = append(result.Result, termData)
+		return
+	}
+
+	result.Summary.add(termData)
+	if termData.Error != nil {
+		result.Result = append(result.Result, termData)
+		result.indexes = append(result.indexes, index)
+	}
+}
@@ -0,0 +1,90 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResultSummary(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithResultSummary(2))
+
+	for i := 0; i < 100; i++ {
+		delay := time.Duration(0)
+		if i == 10 || i == 20 || i == 30 {
+			delay = time.Duration(i) * time.Millisecond
+		}
+
+		var err error
+		if i%25 == 0 {
+			err = errors.New("broken")
+		}
+
+		term.Add(fmt.Sprintf("tenant-%d", i), func(ctx context.Context) error {
+			time.Sleep(delay)
+			return err
+		})
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(2 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	if len(result.Result) != 4 || result.FailedOrTimeoutCount != 4 {
+		t.Fatalf("Expected only the 4 failures to be retained, got %d entries", len(result.Result))
+	}
+	for _, termData := range result.Result {
+		if termData.Status != FAILED {
+			t.Errorf("Unexpected retained result: %+v", termData)
+		}
+	}
+
+	summary := result.Summary
+	if summary == nil || summary.Total != 100 || summary.Counts[SUCCESS] != 96 || summary.Counts[FAILED] != 4 {
+		t.Fatalf("Unexpected summary: %+v", summary)
+	}
+	if len(summary.Slowest) != 2 || summary.Slowest[0].Name != "tenant-30" || summary.Slowest[1].Name != "tenant-20" {
+		t.Errorf("Expected the 2 slowest resources, got %+v", summary.Slowest)
+	}
+
+	if status := term.Status(); status.Closed != 100 || status.Progress != 1 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+}
+
+func TestResultSummaryRetryFailed(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithResultSummary(0))
+
+	attempts := 0
+	term.Add("flaky", func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("busy")
+		}
+		return nil
+	})
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	result, _ := term.Result()
+	retried := result.RetryFailed(context.Background())
+
+	if attempts != 2 || retried.FailedOrTimeoutCount != 0 || retried.Result[0].Name != "flaky" || !retried.Result[0].Retried {
+		t.Fatalf("Expected the flaky resource to be retried, got %+v", retried.Result)
+	}
+	if retried.Summary.Counts[SUCCESS] != 2 || retried.Summary.Counts[FAILED] != 0 {
+		t.Errorf("Expected the retry to update the summary, got %+v", retried.Summary.Counts)
+	}
+	if result.Summary.Counts[FAILED] != 1 {
+		t.Errorf("The original summary shouldn't change, got %+v", result.Summary.Counts)
+	}
+}
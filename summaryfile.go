@@ -0,0 +1,131 @@
+package terminator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ExitSummaryVersion is the version of the schema of ExitSummary, increased
+// on incompatible changes only.
+const ExitSummaryVersion = 1
+
+// WithSummaryFile writes an ExitSummary of the termination as JSON to path at
+// its very end, once the callback returned, for supervisors and CI harnesses
+// to read after the process exited. The file is replaced atomically, so that
+// it is never read half written. It is written as well, with the result so
+// far, when the watchdog exits the process.
+func WithSummaryFile(path string) Option {
+	return func(c *config) {
+		c.summaryFile = path
+	}
+}
+
+// ExitSummary is the content of the file written with WithSummaryFile.
+type ExitSummary struct {
+	SchemaVersion int `json:"schemaVersion"`
+
+	// Reason the termination was triggered, see TerminationResult.Reason
+	Reason string `json:"reason"`
+
+	// Signal received, empty if the termination was not triggered by a signal
+	Signal string `json:"signal,omitempty"`
+
+	// DurationMs is the time from the termination signal until the resources were closed
+	DurationMs int64 `json:"durationMs"`
+
+	// Failed lists the resources that failed, timed out or were interrupted
+	Failed []ExitSummaryEntry `json:"failed"`
+
+	// Aborted is set when the termination was abandoned with Abort
+	Aborted bool `json:"aborted,omitempty"`
+
+	// ExitCode the process exits with, see TerminationResult.ExitCode and WithWatchdog
+	ExitCode int `json:"exitCode"`
+}
+
+// ExitSummaryEntry is a resource that failed to close.
+type ExitSummaryEntry struct {
+	Name   string            `json:"name"`
+	Status TerminationStatus `json:"status"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// ExitCode returns the exit code the process should exit with: 0 once every
+// resource was closed, 1 when some failed or the termination was aborted.
+func (r TerminationResult) ExitCode() int {
+	if r.FailedOrTimeoutCount > 0 || r.Aborted {
+		return 1
+	}
+
+	return 0
+}
+
+// newExitSummary summarizes the result of a termination that took duration.
+func newExitSummary(result TerminationResult, duration time.Duration, exitCode int) ExitSummary {
+	summary := ExitSummary{
+		SchemaVersion: ExitSummaryVersion,
+		Reason:        result.Reason,
+		DurationMs:    duration.Milliseconds(),
+		Failed:        []ExitSummaryEntry{},
+		Aborted:       result.Aborted,
+		ExitCode:      exitCode,
+	}
+
+	if result.Signal != nil {
+		summary.Signal = result.Signal.String()
+	}
+
+	for _, termData := range result.Result {
+		if termData.Error == nil {
+			continue
+		}
+		summary.Failed = append(summary.Failed, ExitSummaryEntry{
+			Name:   termData.Name,
+			Status: termData.Status,
+			Error:  termData.Error.Error(),
+		})
+	}
+
+	return summary
+}
+
+// writeSummaryFile writes the summary file, if any, logging on failure.
+func (t *terminator) writeSummaryFile(result TerminationResult, duration time.Duration, exitCode int) {
+	if t.config.summaryFile == "" {
+		return
+	}
+
+	if err := writeFileAtomic(t.config.summaryFile, newExitSummary(result, duration, exitCode)); err != nil {
+		t.config.logger.Printf("writing the summary file %s: %v", t.config.summaryFile, err)
+	}
+}
+
+// writeFileAtomic writes v as JSON to a temporary file renamed to path.
+func writeFileAtomic(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
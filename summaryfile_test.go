@@ -0,0 +1,106 @@
+package terminator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readSummaryFile(t *testing.T, path string) ExitSummary {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Reading the summary file: %v", err)
+	}
+
+	var summary ExitSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Unexpected summary %s: %v", data, err)
+	}
+
+	return summary
+}
+
+func TestSummaryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "summary.json")
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithSummaryFile(path))
+	term.Add("db", func(ctx context.Context) error { return errors.New("connection reset") })
+	term.Add("cache", func(ctx context.Context) error { return nil })
+
+	var callbackRan bool
+	term.SetCallback(func(result TerminationResult) {
+		_, err := os.Stat(path)
+		callbackRan = os.IsNotExist(err)
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+	if !callbackRan {
+		t.Error("Expected the summary file to be written after the callback")
+	}
+
+	summary := readSummaryFile(t, path)
+	if summary.SchemaVersion != ExitSummaryVersion || summary.Reason != ReasonSignal || summary.Signal != os.Interrupt.String() {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if summary.ExitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", summary.ExitCode)
+	}
+	if len(summary.Failed) != 1 || summary.Failed[0].Name != "db" || summary.Failed[0].Status != FAILED || summary.Failed[0].Error != "connection reset" {
+		t.Errorf("Expected db to be reported as failed, got %+v", summary.Failed)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("Expected the temporary file to be renamed, got %d files", len(files))
+	}
+}
+
+func TestSummaryFileWatchdog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "summary.json")
+
+	exitCode := make(chan int, 1)
+	osExit = func(code int) { exitCode <- code }
+	defer func() { osExit = os.Exit }()
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(&recordingLogger{}),
+		WithWatchdog(50*time.Millisecond, 4), WithSummaryFile(path))
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	term.Add("stuck", func(ctx context.Context) error {
+		<-stuck
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	select {
+	case <-exitCode:
+	case <-time.After(time.Second):
+		t.Fatal("The watchdog should have exited the process")
+	}
+
+	summary := readSummaryFile(t, path)
+	if summary.ExitCode != 4 || summary.DurationMs < 50 {
+		t.Errorf("Expected the watchdog exit code after 50ms, got %+v", summary)
+	}
+}
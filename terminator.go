@@ -4,73 +4,501 @@ package terminator
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// osExit is used to exit the process, replaced in tests.
+var osExit = os.Exit
+
 // payload represents a resource that needs to be closed gracefully.
 type payload struct {
 	Name    string
 	Timeout time.Duration
 	Close   func(context.Context) error
+	Phase   Phase
+
+	// Detached closers ignore the deadline and cancellation of the shutdown.
+	Detached bool
+
+	// Rehearse tears down a staging copy of the resource, see WithRehearsal.
+	Rehearse CloseFunc
+
+	// Group is the concurrency group of the resource, see InGroup.
+	Group string
+
+	// Environments the resource is closed in, all if empty, see WithEnvironments.
+	Environments []string
+
+	// MinDuration under which closing the resource is suspicious, see Heavy.
+	MinDuration time.Duration
+
+	// resource is the object watched by the safety net, see Critical, and
+	// critical tracks it once registered.
+	resource interface{}
+	critical *criticalState
+
+	// id identifies the registration of the resource, see Handle.
+	id uint64
 }
 
 type terminator struct {
-	closersStack  []payload
+	// mu guards closersStack and callbackFunc.
+	mu sync.Mutex
+
+	closersStack []payload
+	nextID       uint64
+
+	// positions indexes the closers stack by id, and removed counts the
+	// entries of the stack left by removals until it is compacted.
+	positions map[uint64]int
+	removed   int
+
+	// hooks is the number of closers in the stack configured through the options.
+	hooks int
+
+	// peakRegistered is the highest number of registered resources, and
+	// gaugeMu serializes the calls of the registration gauge.
+	peakRegistered int
+	gaugeMu        sync.Mutex
+
+	// captureGroups gathers the closers by function literal, see WithLoopCaptureCheck.
+	captureGroups map[uintptr]*captureGroup
+
+	// sealed rejects new registrations, see Seal.
+	sealed bool
+
+	// afterShutdown holds the functions registered with AfterShutdown, until
+	// they ran once the closers stack is closed.
+	afterShutdown    []*afterShutdownFunc
+	afterShutdownRan bool
+
 	signalChan    chan os.Signal
-	completedChan chan bool
+	triggerChan   chan trigger
+	completedChan chan struct{}
 	callbackFunc  func(TerminationResult)
+
+	// closedChan is closed once the resources are closed, before the
+	// callback runs, for the Wait called while callbackRunning is set.
+	closedChan      chan struct{}
+	callbackRunning bool
+
+	// registeredChan is notified whenever a resource is registered.
+	registeredChan   chan struct{}
+	lastRegistration time.Time
+
+	// readyChan is closed by Ready.
+	readyChan chan struct{}
+	readyOnce sync.Once
+
+	// healthMu orders the writes of the health file, see WithHealthFile.
+	healthMu sync.Mutex
+
+	// state is the current stage of the lifecycle.
+	state State
+
+	// closing holds the resources being closed in execution order and result the data collected so far.
+	closing []payload
+	result  *TerminationResult
+
+	// remainingTimeouts holds the sums of the timeouts of the resources from
+	// every index of closing on, for WithBeforeEach.
+	remainingTimeouts []time.Duration
+
+	// triggeredAt is when the termination signal was received.
+	triggeredAt time.Time
+	done        bool
+
+	// running holds the state of the closers currently running.
+	running map[*closerState]struct{}
+
+	callbackOnce sync.Once
+
+	monitorOnce sync.Once
+	config      config
+
+	// signals are the close signals the terminator is subscribed to.
+	signals []os.Signal
+
+	// externalChan delivers the signals of terminators created by NewTerminatorFromChannel.
+	externalChan <-chan os.Signal
+
+	// shutdownChan is closed when the lifecycle leaves StateIdle, at shutdownAt.
+	shutdownChan chan struct{}
+	shutdownAt   time.Time
+
+	// requests and phaseStages hold the shutdown-aware contexts, see RequestContext.
+	requests    *drainStage
+	phaseStages map[Phase]*drainStage
+
+	// abortChan is closed by Abort, which also sets aborted.
+	abortChan chan struct{}
+	aborted   bool
+
+	// pauseChan is set while the termination is paused since pausedAt, and
+	// closed by Resume, see Pause.
+	pauseChan chan struct{}
+	pausedAt  time.Time
+
+	// budgetDeadline is when the shutdown budget is spent, zero without budget.
+	budgetDeadline time.Time
+
+	// unregisterSignals removes the close signals from the signal handler registry.
+	unregisterSignals func()
 }
 
 // NewTerminator creates a new instance of the terminator.
-func NewTerminator(closeSignals []os.Signal) Terminator {
-	sigc := make(chan os.Signal, 1)
-	signal.Notify(sigc, closeSignals...)
+// The monitor waiting for the close signals is started right away, so that a
+// signal received before anything is registered still completes the
+// termination and runs the callback, see WithRegistrationGrace.
+// Signals that cannot be caught, such as os.Kill, are ignored with a warning.
+// Unlike signal.Notify, the terminator subscribes to no signal when
+// closeSignals is empty, rather than to every signal: the termination is then
+// only triggered from code, such as with Terminate, or by a trigger source.
+// Earlier versions relayed every signal in that case.
+func NewTerminator(closeSignals []os.Signal, opts ...Option) Terminator {
+	term := newTerminator(opts)
+
+	signals := catchableSignals(closeSignals, term.config.logger)
+	if len(signals) > 0 {
+		if term.config.exclusiveSignals {
+			resetSignals(signals, term.config.logger)
+		}
+
+		signal.Notify(term.signalChan, signals...)
+		term.signals = signals
+		term.unregisterSignals = RegisterSignalHandler("terminator", signals...)
+	}
+	term.ensureMonitor()
 
+	return term
+}
+
+// NewTerminatorFromChannel creates a new instance of the terminator fed by
+// the signals received from ch, for applications that already own the signal
+// handling. The terminator never calls signal.Notify itself, so
+// WithExclusiveSignals has no effect. Every value received from ch triggers
+// the termination; closing ch only stops the terminator from listening to it.
+func NewTerminatorFromChannel(ch <-chan os.Signal, opts ...Option) Terminator {
+	term := newTerminator(opts)
+	term.externalChan = ch
+	term.ensureMonitor()
+
+	return term
+}
+
+// newTerminator creates a terminator configured by opts, without subscribing to any signal.
+func newTerminator(opts []Option) *terminator {
 	term := &terminator{
-		signalChan:    sigc,
-		completedChan: make(chan bool, 1),
+		signalChan:     make(chan os.Signal, 1),
+		triggerChan:    make(chan trigger, 1),
+		completedChan:  make(chan struct{}),
+		closedChan:     make(chan struct{}),
+		registeredChan: make(chan struct{}, 1),
+		readyChan:      make(chan struct{}),
+		abortChan:      make(chan struct{}),
+		shutdownChan:   make(chan struct{}),
+		requests:       newDrainStage(),
+		phaseStages:    make(map[Phase]*drainStage),
+		running:        make(map[*closerState]struct{}),
+		positions:      make(map[uint64]int),
+		state:          StateIdle,
+		config:         defaultConfig(),
+	}
+
+	for _, opt := range opts {
+		opt(&term.config)
 	}
+	if term.config.envConfig {
+		term.config.applyEnv()
+	}
+	term.pushAnnouncers()
+	term.pushResigners()
+	term.markUnhealthy()
 
-	go term.startMonitor()
+	if term.config.gcSafetyNet && !cleanupSupported {
+		term.config.logger.Printf("the safety net of the critical resources requires Go 1.24: it is disabled")
+	}
 
 	return term
 }
 
 // Add registers a resource with the terminator to be closed without any timeout.
-func (t *terminator) Add(name string, close CloseFunc) {
-	t.AddWithTimeout(name, close, 0)
+func (t *terminator) Add(name string, close CloseFunc) (*Handle, error) {
+	return t.AddWithTimeout(name, close, 0)
 }
 
 // AddWithTimeout registers a resource with the terminator to be closed with a specified timeout.
-func (t *terminator) AddWithTimeout(name string, close CloseFunc, timeout time.Duration) {
-	t.closersStack = append(t.closersStack, payload{Name: name, Close: close, Timeout: timeout})
+func (t *terminator) AddWithTimeout(name string, close CloseFunc, timeout time.Duration) (*Handle, error) {
+	handle, err := t.push(payload{Name: name, Close: close, Timeout: timeout})
+	t.ensureMonitor()
+
+	return handle, err
 }
 
-// SetCallback sets the callback function to be executed after all resources are closed.
+// AddWithOptions registers a resource with the terminator to be closed as configured by the options.
+func (t *terminator) AddWithOptions(name string, close CloseFunc, opts ...CloserOption) (*Handle, error) {
+	closer := payload{Name: name, Close: close}
+	for _, opt := range opts {
+		opt(&closer)
+	}
+	t.watchCritical(&closer)
+
+	handle, err := t.push(closer)
+	t.ensureMonitor()
+
+	return handle, err
+}
+
+// AddWithPriority registers a resource closed before the resources of lower
+// priority, whatever the order they were registered in, which is fragile
+// across packages. Resources of the same priority are closed in reverse
+// order of registration. The priority is the opposite of the phase of the
+// resource, so a priority of 0 is DefaultPhase and PhaseIngress is a
+// priority of 300. The priority is clamped between -1999 and 699, so that a
+// resource is never closed before PhaseAnnounce nor gets the semantics of
+// PhaseFinalizer: use AddWithOptions with InPhase for those.
+func (t *terminator) AddWithPriority(name string, close CloseFunc, priority int) (*Handle, error) {
+	phase := Phase(-priority)
+	if phase <= PhaseAnnounce {
+		phase = PhaseAnnounce + 1
+	} else if phase >= PhaseFinalizer {
+		phase = PhaseFinalizer - 1
+	}
+
+	return t.AddWithOptions(name, close, InPhase(phase))
+}
+
+// push appends the resource to the closers stack. It logs a warning and
+// returns ErrSealed once the registrations are sealed, unless the resource
+// is closed right away, see WithCloseLateRegistrations.
+func (t *terminator) push(closer payload) (*Handle, error) {
+	if t.config.standardPhases && !closer.Phase.IsStandard() {
+		t.config.logger.Printf("resource %q is registered in the non-standard %v", closer.Name, closer.Phase)
+	}
+
+	t.mu.Lock()
+	if t.sealed {
+		closing := t.result != nil
+		t.mu.Unlock()
+		if closing && t.config.closeLateRegistrations {
+			return nil, t.closeLate(closer)
+		}
+		t.config.logger.Printf("resource %q is rejected: the registrations are sealed and it would never be closed", closer.Name)
+		return nil, ErrSealed
+	}
+
+	t.nextID++
+	closer.id = t.nextID
+	t.pushLocked(closer)
+	sharing, captureWarning := t.checkLoopCapture(closer)
+	t.mu.Unlock()
+
+	if captureWarning {
+		t.config.logger.Printf("resource %q is one of %d closers capturing the same variable: check that they do not capture a loop variable", closer.Name, sharing)
+	}
+	t.emitRegistrations()
+
+	return &Handle{term: t, closer: closer}, nil
+}
+
+// Seal freezes the registrations, see Terminator.Seal.
+func (t *terminator) Seal() {
+	t.mu.Lock()
+	t.sealed = true
+	t.mu.Unlock()
+}
+
+// pushLocked appends the resource to the closers stack while t.mu is held.
+func (t *terminator) pushLocked(closer payload) {
+	t.positions[closer.id] = len(t.closersStack)
+	t.closersStack = append(t.closersStack, closer)
+	t.lastRegistration = time.Now()
+
+	if registered := t.registeredLocked(); registered > t.peakRegistered {
+		t.peakRegistered = registered
+	}
+
+	select {
+	case t.registeredChan <- struct{}{}:
+	default:
+	}
+}
+
+// Ready marks the startup as complete, so a termination signal no longer
+// waits for registrations to settle.
+func (t *terminator) Ready() {
+	t.readyOnce.Do(func() {
+		close(t.readyChan)
+		t.markHealthy()
+	})
+}
+
+// SetCallback sets the callback function to be executed after all resources
+// are closed. The termination completes once the callback returned, so Wait
+// returns after it, except when called while the callback runs, such as from
+// the callback itself or a goroutine it started: Wait then waits for the
+// resources to be closed only.
 func (t *terminator) SetCallback(fn func(TerminationResult)) {
+	t.mu.Lock()
 	t.callbackFunc = fn
+	t.mu.Unlock()
 }
 
-// Wait waits for the termination process to complete with a specified timeout duration.
+// Wait waits for the termination process to complete with a specified
+// timeout duration. A zero timeout waits until it completed, however long it
+// takes, as in defer term.Wait(0), and a negative timeout does not wait: it
+// only reports whether the termination completed.
 func (t *terminator) Wait(timeout time.Duration) bool {
+	return !errors.Is(t.WaitErr(timeout), ErrWaitTimeout)
+}
+
+// WaitErr waits for the termination process to complete with a specified
+// timeout duration, like Wait. It returns nil once the termination completed,
+// ErrAborted if it completed after being aborted, or ErrWaitTimeout.
+func (t *terminator) WaitErr(timeout time.Duration) error {
+	t.ensureMonitor()
+
+	done, inCallback := t.completedChan, t.inCallback()
+	if inCallback {
+		done = t.closedChan
+	}
+
+	if timeout < 0 {
+		select {
+		case <-done:
+			return t.completedErr()
+		default:
+			return ErrWaitTimeout
+		}
+	}
+
+	// A nil channel never fires, waiting forever with a zero timeout.
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
 	select {
-	case <-t.completedChan:
-		return true
-	case <-time.After(timeout):
-		return false
+	case <-done:
+		return t.completedErr()
+	case <-expired:
+		if !inCallback {
+			t.waitTimedOut()
+		}
+		return ErrWaitTimeout
+	}
+}
+
+// completedErr returns the error of WaitErr once the termination completed.
+func (t *terminator) completedErr() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.result != nil && t.result.Aborted {
+		return ErrAborted
 	}
+
+	return nil
+}
+
+// waitTimedOut delivers the partial result to the callback when Wait times
+// out, if configured with WithCallbackOnWaitTimeout.
+func (t *terminator) waitTimedOut() {
+	if t.config.callbackOnWaitTimeout {
+		if result, ok := t.Result(); ok {
+			t.runCallback(result)
+		}
+	}
+}
+
+// Result returns the result of the termination process, which is partial while it is still running.
+func (t *terminator) Result() (TerminationResult, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.result == nil {
+		return TerminationResult{}, false
+	}
+
+	result := *t.result
+	result.Result = append([]TerminationResultData(nil), t.result.Result...)
+	result.Summary = t.result.Summary.clone()
+	result.indexes = append([]int(nil), t.result.indexes...)
+	result.HungHooks = append([]string(nil), t.result.HungHooks...)
+
+	if !t.state.IsTerminal() && t.state != StateFinalizing {
+		result.Partial = true
+		if result.Summary != nil {
+			return result, true
+		}
+		for _, closer := range t.closing[result.closed:] {
+			result.Result = append(result.Result, TerminationResultData{
+				Name:   closer.Name,
+				Status: PENDING,
+			})
+		}
+	}
+
+	return result, true
+}
+
+// runCallback invokes the callback, if any, at most once.
+func (t *terminator) runCallback(result TerminationResult) {
+	t.mu.Lock()
+	callbackFunc := t.callbackFunc
+	t.mu.Unlock()
+
+	if callbackFunc == nil {
+		return
+	}
+
+	t.callbackOnce.Do(func() {
+		t.runHook(HookCallback, func() {
+			t.setCallbackRunning(true)
+			defer t.setCallbackRunning(false)
+
+			callbackFunc(result)
+		})
+	})
 }
 
 // closeStack performs the actual closing of a single resource in a separate goroutine.
-func (t *terminator) closeStack(closer *payload) <-chan TerminationResultData {
+// Every invocation derives its own context from parent, so closers never share a context.
+// Tracked invocations are reported in the Status and the events of the termination.
+func (t *terminator) closeStack(parent context.Context, closer *payload, tracked bool) <-chan TerminationResultData {
 	result := make(chan TerminationResultData, 1)
 
-	ctx := context.Background()
+	closer.settle()
 
 	go func() {
-		name := closer.Name
+		if closer.Detached {
+			parent = detachedContext{parent: parent}
+		}
+
+		// Finalizers run to completion even when the termination is aborted.
+		if !closer.isFinalizer() {
+			var cancel context.CancelFunc
+			parent, cancel = t.abortable(parent)
+			defer cancel()
+		}
+
+		ctx, state := withCloserState(parent, closer.Name, t)
+
+		if tracked {
+			t.mu.Lock()
+			t.running[state] = struct{}{}
+			t.mu.Unlock()
+			t.emit(Event{Kind: EventCloserStarted, Name: closer.Name})
+		}
+
 		// Apply timeout to the resource's closing if specified.
 		if closer.Timeout > 0 {
 			var cancel context.CancelFunc
@@ -81,76 +509,344 @@ func (t *terminator) closeStack(closer *payload) <-chan TerminationResultData {
 		var status TerminationStatus
 		var err error
 
+		var usage *usageSample
+		if t.config.resourceAccounting {
+			usage = sampleUsage()
+		}
+		start := time.Now()
+
+		// Close runs in its own goroutine, so a closer ignoring the context
+		// cannot hold the termination past its timeout. The usage is sampled
+		// from that goroutine as well, so that its own start and exit do not
+		// offset the goroutines started by the closer.
+		var closerUsage *ResourceUsage
 		errChan := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errChan <- &PanicError{Value: r, Stack: debug.Stack()}
+				}
+			}()
+
+			var begin *usageSample
+			if usage != nil {
+				begin = sampleUsage()
+			}
+			err := closer.Close(ctx)
+			if begin != nil {
+				closerUsage = begin.since()
+			}
+			errChan <- err
+		}()
+
+		closed := false
 		select {
+		case err = <-errChan:
+			closed = true
 		case <-ctx.Done():
-			err = ctx.Err()
-			// If context has no error, then run close again.
-			if err == nil {
-				err = closer.Close(ctx)
+			// Prefer the closer's own result if it finished in the meantime.
+			select {
+			case err = <-errChan:
+				closed = true
+			default:
+				err = ctx.Err()
 			}
-		case errChan <- closer.Close(ctx):
-			err = <-errChan
 		}
 
+		termData := TerminationResultData{
+			Name:     closer.Name,
+			Error:    err,
+			Kind:     ClassifyError(err),
+			Detached: closer.Detached,
+			Timeout:  closer.Timeout,
+			Steps:    int(atomic.LoadInt64(&state.steps)),
+			Duration: time.Since(start),
+		}
+
+		if closed && closerUsage != nil {
+			termData.Usage = closerUsage
+		} else if usage != nil {
+			termData.Usage = usage.since()
+		}
+
+		termData.SubResults = state.loadSubResults()
+
 		if err == nil {
 			status = SUCCESS
 		} else {
 			status = FAILED
 		}
+		termData.Status = status
 
-		result <- TerminationResultData{
-			Name:   name,
-			Status: status,
-			Error:  err,
+		if tracked {
+			t.mu.Lock()
+			delete(t.running, state)
+			t.mu.Unlock()
 		}
 
+		result <- termData
+
 	}()
 
 	return result
 }
 
-// closeAll closes all the registered resources and collects the termination result data.
-func (t *terminator) closeAll(ctx context.Context, result *TerminationResult) {
+// closeAll closes all the given resources in execution order, one phase
+// after another, collects the termination result data and returns the
+// duration of every phase.
+func (t *terminator) closeAll(ctx context.Context, closers []payload, result *TerminationResult) []PhaseReport {
+	var phases []PhaseReport
 
-	var stackIndex int
-
-	for stackIndex = len(t.closersStack) - 1; stackIndex >= 0; stackIndex-- {
-
-		termData := <-t.closeStack(&t.closersStack[stackIndex])
+	for start := 0; start < len(closers); {
+		end := start + 1
+		for end < len(closers) && closers[end].Phase == closers[start].Phase {
+			end++
+		}
 
-		if termData.Error != nil {
-			result.FailedOrTimeoutCount++
+		phaseStart := time.Now()
+		t.drainPhase(closers[start].Phase)
+		if t.config.parallelPhases {
+			t.closePhase(ctx, closers[start:end], result)
+		} else {
+			t.closeInOrder(ctx, closers[start:end], result)
 		}
+		phases = append(phases, PhaseReport{Phase: closers[start].Phase, Duration: time.Since(phaseStart)})
 
-		result.Result = append(result.Result, termData)
+		start = end
 	}
 
+	return phases
+}
+
+// closeInOrder closes the given resources one at a time.
+func (t *terminator) closeInOrder(ctx context.Context, closers []payload, result *TerminationResult) {
+	for index := range closers {
+
+		termData := t.closeOne(ctx, &closers[index])
+
+		t.mu.Lock()
+		t.record(result, result.closed, termData)
+		t.mu.Unlock()
+
+		t.emit(Event{Kind: EventCloserFinished, Name: termData.Name, CloserProgress: 1, Data: &termData})
+	}
 }
 
 // unsubscribe stops listening to termination signals.
 func (t *terminator) unsubscribe() {
 	signal.Stop(t.signalChan)
+
+	if t.unregisterSignals != nil {
+		t.unregisterSignals()
+	}
+}
+
+// IsReady reports whether Ready was called and the termination process has not started yet.
+func (t *terminator) IsReady() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.isReady() && t.state == StateIdle
+}
+
+// isReady reports whether Ready was called.
+func (t *terminator) isReady() bool {
+	select {
+	case <-t.readyChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// ensureMonitor starts the monitor goroutine exactly once.
+func (t *terminator) ensureMonitor() {
+	t.monitorOnce.Do(func() {
+		go t.startMonitor()
+	})
+}
+
+// awaitRegistration handles a signal received during startup. Unless Ready
+// was called, it waits up to the registration grace period until at least one
+// resource is registered and no registration happened for registrationSettle.
+func (t *terminator) awaitRegistration() {
+	if t.config.registrationGrace <= 0 {
+		return
+	}
+
+	deadline := time.Now().Add(t.config.registrationGrace)
+
+	for {
+		t.mu.Lock()
+		registered := len(t.closersStack)-t.removed > t.hooks
+		settleAt := t.lastRegistration.Add(registrationSettle)
+		t.mu.Unlock()
+
+		now := time.Now()
+		if registered && !now.Before(settleAt) {
+			return
+		}
+
+		wakeAt := deadline
+		if registered && settleAt.Before(wakeAt) {
+			wakeAt = settleAt
+		}
+
+		if !now.Before(deadline) {
+			return
+		}
+
+		timer := time.NewTimer(wakeAt.Sub(now))
+		select {
+		case <-t.readyChan:
+			timer.Stop()
+			return
+		case <-t.abortChan:
+			timer.Stop()
+			return
+		case <-t.registeredChan:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
 }
 
 // startMonitor starts monitoring for termination signals and initiates the termination process.
 func (t *terminator) startMonitor() {
+	stopTriggerSources := t.startTriggerSources()
+
+	var trig trigger
+	var triggeredAt time.Time
+	var deferred *quiesceTimer
+	for external := t.externalChan; trig.reason == ""; {
+		select {
+		case s := <-t.signalChan:
+			trig = trigger{signal: s, reason: ReasonSignal}
+		case s, ok := <-external:
+			if !ok {
+				external = nil
+				continue
+			}
+			trig = trigger{signal: s, reason: ReasonSignal}
+		case received := <-t.triggerChan:
+			if received.deferrable {
+				if deferred == nil {
+					deferred = t.deferUntilQuiet(received)
+				}
+				if deferred != nil {
+					continue
+				}
+			}
+			trig = received
+		case <-deferred.C():
+			trig = deferred.trig
+		}
+		triggeredAt = time.Now()
+	}
+	deferred.Stop()
+	stopTriggerSources()
+
+	t.transition(StateDraining)
+	t.markUnhealthy()
+
+	if t.config.exitBeforeReady && !t.isReady() {
+		t.transition(StateAborted)
+		t.unsubscribe()
+		osExit(t.config.notReadyExitCode)
+		return
+	}
+
+	stopWatchdog := t.startWatchdog()
+	defer stopWatchdog()
+
+	t.startRequestGrace()
+	t.awaitRegistration()
+	t.waitJitter()
+	t.drainRequests()
 
-	s := <-t.signalChan
+	t.mu.Lock()
+	t.sealed = true
+	closers := executionOrder(t.closersStack)
 
 	// Initializing Result
-	result := TerminationResult{
-		Signal: s,
-		Result: make([]TerminationResultData, 0, len(t.closersStack)),
+	result := &TerminationResult{
+		Signal:       trig.signal,
+		Reason:       trig.reason,
+		Programmatic: trig.programmatic,
+		cause:        trig.cause,
+		term:         t,
+		closers:      closers,
+	}
+	if t.config.resultSummary {
+		result.Summary = newResultSummary(t.config.summarySlowest)
+	} else {
+		result.Result = make([]TerminationResultData, 0, len(closers))
+	}
+	t.closing = closers
+	t.result = result
+	if t.config.beforeEach != nil {
+		t.remainingTimeouts = remainingTimeouts(closers)
+	}
+	t.triggeredAt = triggeredAt
+	t.mu.Unlock()
+
+	t.transition(StateClosing)
+	t.emit(Event{Kind: EventShutdownStarted})
+
+	ctx := trig.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = withValueStore(ctx)
+	if t.config.shutdownBudget > 0 {
+		t.mu.Lock()
+		t.budgetDeadline = time.Now().Add(t.config.shutdownBudget)
+		t.mu.Unlock()
 	}
 
-	ctx := context.Background()
+	notifyCtx, cancelNotify := t.notifyContext()
+	defer cancelNotify()
+	webhookStarted := t.notifyWebhooks(notifyCtx, newWebhookPayload(WebhookStarted, trig.reason, trig.signal))
+
+	closingAt := time.Now()
+	phases := t.closeAll(ctx, closers, result)
+	closedAt := time.Now()
+	duration := closedAt.Sub(triggeredAt)
+	t.cancelPhaseStages()
+	t.runAfterShutdowns()
+
+	t.transition(StateFinalizing)
+
+	// Abort cannot succeed once finalizing, so the outcome is settled.
+	t.mu.Lock()
+	result.Aborted = t.aborted
+	result.SLO = t.config.sloReport(duration, phases, result.SlowestN(reportedSlowest))
+	result.AnnounceLatency = announceLatency(closingAt.Sub(triggeredAt), phases)
+	result.BudgetOverrun = t.budgetOverrunLocked(closedAt, duration)
+	result.ExceededBudget = result.BudgetOverrun > 0
+	t.mu.Unlock()
+
+	t.emit(Event{Kind: EventShutdownCompleted})
+
+	close(t.closedChan)
+	if final, ok := t.Result(); ok {
+		t.runCallback(final)
+	}
+
+	if result.Aborted {
+		t.transition(StateAborted)
+	} else {
+		t.transition(StateDone)
+	}
 
-	t.closeAll(ctx, &result)
+	if final, ok := t.Result(); ok {
+		t.writeSummaryFile(final, final.SLO.Duration, final.ExitCode())
 
-	if t.callbackFunc != nil {
-		t.callbackFunc(result)
+		<-webhookStarted
+		<-t.notifyWebhooks(notifyCtx, completedWebhookPayload(final))
+		t.report(final)
 	}
+	t.quitSidecars()
 
 	t.unsubscribe()
 	close(t.completedChan)
@@ -0,0 +1,77 @@
+//go:build !windows
+// +build !windows
+
+package terminator
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSelfTestProbeSignal(t *testing.T) {
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer signal.Stop(resize)
+
+	term := NewTerminator([]os.Signal{os.Interrupt})
+	if report := term.SelfTest(time.Second); !report.Delivered {
+		t.Fatalf("Expected the probe signal to be delivered, got %+v", report)
+	}
+
+	select {
+	case sig := <-resize:
+		t.Errorf("The probe shouldn't be delivered to the handlers of %v", sig)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSelfTestProbeBlocked(t *testing.T) {
+	defer func(send func() error) { sendProbe = send }(sendProbe)
+	sendProbe = func() error { return nil }
+
+	term := NewTerminator([]os.Signal{os.Interrupt})
+	report := term.SelfTest(100 * time.Millisecond)
+
+	if !report.DeliveryChecked || report.Delivered {
+		t.Errorf("Expected the blocked probe not to be reported as delivered, got %+v", report)
+	}
+	if report.OK() || !strings.Contains(report.Problems[0], "not delivered") {
+		t.Errorf("Expected a problem about the probe signal, got %v", report.Problems)
+	}
+}
+
+func TestExclusiveSignals(t *testing.T) {
+	library := make(chan os.Signal, 1)
+	signal.Notify(library, syscall.SIGUSR1)
+	defer signal.Stop(library)
+
+	unregister := RegisterSignalHandler("library", syscall.SIGUSR1)
+	defer unregister()
+
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{syscall.SIGUSR1}, WithExclusiveSignals(), WithLogger(logger), WithRegistrationGrace(0))
+	term.Add("app1", func(ctx context.Context) error { return nil })
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "library") {
+		t.Errorf("Expected a warning about the library handler, got %q", logger.lines)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	select {
+	case sig := <-library:
+		t.Errorf("The library handler shouldn't receive %v", sig)
+	default:
+	}
+}
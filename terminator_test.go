@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"strconv"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -52,6 +53,92 @@ func TestWaitTimeout(t *testing.T) {
 	}
 }
 
+func TestWaitErr(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	term.Add("app1", func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	if err := term.WaitErr(10 * time.Millisecond); err != ErrWaitTimeout {
+		t.Errorf("Expected %v, got %v", ErrWaitTimeout, err)
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	<-started
+	close(release)
+
+	if err := term.WaitErr(time.Second); err != nil {
+		t.Errorf("Expected the termination to complete, got %v", err)
+	}
+}
+
+func TestWaitZeroAndNegativeTimeout(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	release := make(chan struct{})
+	term.Add("app1", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	if term.Wait(-1) {
+		t.Error("Expected a negative timeout to report the termination as not completed")
+	}
+
+	waited := make(chan bool, 1)
+	go func() {
+		waited <- term.Wait(0)
+	}()
+
+	term.(*terminator).signalChan <- os.Interrupt
+	select {
+	case <-waited:
+		t.Fatal("Expected a zero timeout to wait for the termination to complete")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case ok := <-waited:
+		if !ok {
+			t.Error("Expected Wait(0) to report the completion")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Termination timed out")
+	}
+
+	if !term.Wait(-1) {
+		t.Error("Expected a negative timeout to report the termination as completed")
+	}
+}
+
+func TestWaitErrAborted(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	started := make(chan struct{})
+	term.Add("app1", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+	<-started
+	term.Abort()
+
+	if err := term.WaitErr(time.Second); err != ErrAborted {
+		t.Errorf("Expected %v, got %v", ErrAborted, err)
+	}
+	if !term.Wait(time.Second) {
+		t.Error("Wait should report an aborted termination as completed")
+	}
+}
+
 func TestExecutionOrder(t *testing.T) {
 	term := NewTerminator([]os.Signal{os.Interrupt})
 
@@ -136,3 +223,328 @@ func TestCallback(t *testing.T) {
 		return
 	}
 }
+
+func TestSignalBeforeRegistration(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(2*time.Second))
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	// Wait starts the monitor before anything is registered.
+	if term.Wait(100 * time.Millisecond) {
+		t.Error("Wait should have timed out while waiting for registrations")
+		return
+	}
+
+	closed := make(chan bool, 1)
+	term.Add("app1", func(ctx context.Context) error {
+		closed <- true
+		return nil
+	})
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	select {
+	case <-closed:
+	default:
+		t.Error("app1 registered during the grace period not closed")
+	}
+}
+
+func TestSignalWithoutRegistration(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	called := make(chan struct{})
+	term.SetCallback(func(result TerminationResult) { close(called) })
+
+	// Neither Add nor Wait is called: the monitor runs nonetheless.
+	term.(*terminator).signalChan <- os.Interrupt
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the callback to run on a signal with nothing registered")
+	}
+}
+
+func TestNoRegistrationGrace(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"default": nil,
+		"zero":    {WithRegistrationGrace(0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			term := NewTerminator([]os.Signal{os.Interrupt}, opts...)
+
+			termInternal := term.(*terminator)
+			termInternal.signalChan <- os.Interrupt
+
+			if !term.Wait(100 * time.Millisecond) {
+				t.Error("Wait shouldn't time out without a registration grace")
+			}
+		})
+	}
+}
+
+func TestReadyEndsRegistrationGrace(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(5*time.Second))
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	closed := make(chan bool, 1)
+	term.Add("app1", func(ctx context.Context) error {
+		closed <- true
+		return nil
+	})
+	term.Ready()
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out after Ready")
+		return
+	}
+
+	select {
+	case <-closed:
+	default:
+		t.Error("app1 not closed")
+	}
+}
+
+func TestNotReadyExit(t *testing.T) {
+	exitCode := make(chan int, 1)
+	osExit = func(code int) { exitCode <- code }
+	defer func() { osExit = os.Exit }()
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithNotReadyExit(3))
+
+	closed := false
+	term.Add("app1", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	select {
+	case code := <-exitCode:
+		if code != 3 {
+			t.Errorf("Expected exit code 3, got %d", code)
+		}
+	case <-time.After(1 * time.Second):
+		t.Error("Process should have exited before Ready")
+		return
+	}
+
+	if closed {
+		t.Error("app1 shouldn't be closed when exiting before Ready")
+	}
+}
+
+func TestCallbackOnWaitTimeout(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithCallbackOnWaitTimeout())
+
+	release := make(chan struct{})
+	term.Add("slow", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	term.Add("fast", func(ctx context.Context) error {
+		return nil
+	})
+
+	callbacks := make(chan TerminationResult, 2)
+	term.SetCallback(func(result TerminationResult) {
+		callbacks <- result
+	})
+
+	if _, ok := term.Result(); ok {
+		t.Error("Result shouldn't be available before termination")
+	}
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if term.Wait(500 * time.Millisecond) {
+		t.Error("Wait should have timed out")
+		return
+	}
+
+	var partial TerminationResult
+	select {
+	case partial = <-callbacks:
+	default:
+		t.Error("Callback should receive the partial result")
+		return
+	}
+
+	if !partial.Partial || len(partial.Result) != 2 {
+		t.Errorf("Unexpected partial result: %+v", partial)
+		return
+	}
+
+	if partial.Result[0].Name != "fast" || partial.Result[0].Status != SUCCESS {
+		t.Errorf("fast should be closed, got %+v", partial.Result[0])
+	}
+
+	if partial.Result[1].Name != "slow" || partial.Result[1].Status != PENDING {
+		t.Errorf("slow should be pending, got %+v", partial.Result[1])
+	}
+
+	close(release)
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	final, ok := term.Result()
+	if !ok || final.Partial || final.Result[1].Status != SUCCESS {
+		t.Errorf("Unexpected final result: %+v", final)
+	}
+
+	select {
+	case <-callbacks:
+		t.Error("Callback should be invoked only once")
+	default:
+	}
+}
+
+func TestCloserTimeout(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	// Ignores its context and outlives its timeout.
+	term.AddWithTimeout("stubborn", func(ctx context.Context) error {
+		time.Sleep(2 * time.Second)
+		return nil
+	}, 100*time.Millisecond)
+
+	// Honours its context.
+	term.AddWithTimeout("cooperative", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, 100*time.Millisecond)
+
+	var result TerminationResult
+	term.SetCallback(func(r TerminationResult) {
+		result = r
+	})
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out when closers exceed their timeout")
+		return
+	}
+
+	if result.FailedOrTimeoutCount != 2 {
+		t.Errorf("Expected 2 timed out closers, got %d", result.FailedOrTimeoutCount)
+	}
+
+	for _, data := range result.Result {
+		if data.Status != FAILED || data.Error != context.DeadlineExceeded {
+			t.Errorf("%s should have timed out, got %v", data.Name, data.Error)
+		}
+	}
+}
+
+func TestConcurrentTimeouts(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+		for j := 0; j < 5; j++ {
+			term.AddWithTimeout("app"+strconv.Itoa(j), func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}, 10*time.Millisecond)
+		}
+
+		termInternal := term.(*terminator)
+		termInternal.signalChan <- os.Interrupt
+
+		if !term.Wait(1 * time.Second) {
+			t.Error("Wait shouldn't time out")
+			return
+		}
+	}
+}
+
+func TestNewTerminatorFromChannel(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	term := NewTerminatorFromChannel(signals, WithRegistrationGrace(0))
+
+	closed := false
+	term.Add("app1", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	signals <- syscall.SIGTERM
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+
+	result, _ := term.Result()
+	if !closed || result.Signal != syscall.SIGTERM || result.Reason != ReasonSignal {
+		t.Errorf("Expected app1 to be closed on SIGTERM, got %+v", result)
+	}
+}
+
+func TestNewTerminatorFromClosedChannel(t *testing.T) {
+	signals := make(chan os.Signal)
+	term := NewTerminatorFromChannel(signals, WithRegistrationGrace(0))
+	term.Add("app1", func(ctx context.Context) error { return nil })
+
+	close(signals)
+
+	if term.Wait(100 * time.Millisecond) {
+		t.Fatal("Closing the channel shouldn't trigger the termination")
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("Wait shouldn't time out")
+	}
+}
+
+func TestTerminate(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	closed := false
+	term.Add("db", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	if !term.Terminate("admin-rpc") {
+		t.Fatal("Expected the termination to be triggered")
+	}
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+	if term.Terminate("again") {
+		t.Error("Expected the termination not to be triggered twice")
+	}
+
+	result, _ := term.Result()
+	if !closed || !result.Programmatic || result.Reason != "admin-rpc" || result.Signal != nil {
+		t.Errorf("Expected a programmatic termination closing the resources, got %+v", result)
+	}
+}
+
+func TestTerminateWithoutReason(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	term.Terminate("")
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if result, _ := term.Result(); result.Reason != ReasonProgrammatic {
+		t.Errorf("Expected the reason %s, got %s", ReasonProgrammatic, result.Reason)
+	}
+}
@@ -0,0 +1,138 @@
+package terminatortest
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// childEnv holds the name of the test the child process should run.
+	childEnv = "TERMINATORTEST_SIGNAL_CHILD"
+
+	// readyMarker is printed by the child once it is ready to receive a signal.
+	readyMarker = "TERMINATORTEST_SIGNAL_READY"
+)
+
+// ErrSignalTimeout is returned when the child process does not become ready
+// or does not exit within the given timeout.
+var ErrSignalTimeout = errors.New("terminatortest: child process timed out")
+
+// ErrSignalNotReady is returned when the child process exits before calling
+// SignalReady.
+var ErrSignalNotReady = errors.New("terminatortest: child process exited before becoming ready")
+
+// SignalResult holds the outcome of a child process run by RunWithSignal.
+type SignalResult struct {
+
+	// Output written by the child to stdout and stderr, without the ready marker
+	Output string
+
+	// Exit code of the child process
+	ExitCode int
+}
+
+// IsSignalChild reports whether the current process was spawned by
+// RunWithSignal, in which case the test should set up the terminator, call
+// SignalReady and wait for the termination.
+func IsSignalChild() bool {
+	return os.Getenv(childEnv) != ""
+}
+
+// SignalReady notifies the parent process that the child has finished its
+// setup and is ready to receive the signal.
+func SignalReady() {
+	fmt.Println(readyMarker)
+}
+
+// RunWithSignal re-executes the current test binary running only the test
+// named testName, waits until the child calls SignalReady, delivers sig to it
+// and waits for the child to exit. The whole run must complete within
+// timeout. It tests the signal.Notify wiring of a program end to end, with
+// real OS signals.
+func RunWithSignal(testName string, sig os.Signal, timeout time.Duration) (SignalResult, error) {
+	cmd := exec.Command(os.Args[0], "-test.run=^"+testName+"$", "-test.v")
+	cmd.Env = append(os.Environ(), childEnv+"="+testName)
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		return SignalResult{}, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		exited <- err
+	}()
+
+	var output bytes.Buffer
+	readyChan := make(chan struct{})
+	scanned := make(chan struct{})
+	go func() {
+		defer close(scanned)
+
+		ready := false
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !ready && strings.TrimSpace(line) == readyMarker {
+				ready = true
+				close(readyChan)
+				continue
+			}
+			output.WriteString(line)
+			output.WriteByte('\n')
+		}
+	}()
+
+	deadline := time.After(timeout)
+
+	select {
+	case <-readyChan:
+	case <-scanned:
+		// The child exited without ever becoming ready.
+		return SignalResult{Output: output.String(), ExitCode: -1}, ErrSignalNotReady
+	case <-deadline:
+		cmd.Process.Kill()
+		<-scanned
+		return SignalResult{Output: output.String(), ExitCode: -1}, ErrSignalTimeout
+	}
+
+	if err := cmd.Process.Signal(sig); err != nil {
+		cmd.Process.Kill()
+		<-scanned
+		return SignalResult{Output: output.String(), ExitCode: -1}, err
+	}
+
+	var err error
+	select {
+	case err = <-exited:
+	case <-deadline:
+		cmd.Process.Kill()
+		<-scanned
+		return SignalResult{Output: output.String(), ExitCode: -1}, ErrSignalTimeout
+	}
+
+	<-scanned
+
+	result := SignalResult{Output: output.String()}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return result, err
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+//go:build !windows
+// +build !windows
+
+package terminatortest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// TestSignalChild is executed as a child process by TestRunWithSignal.
+func TestSignalChild(t *testing.T) {
+	if !IsSignalChild() {
+		t.Skip("only runs as a child of the real signal tests")
+	}
+
+	term := terminator.NewTerminator([]os.Signal{syscall.SIGINT, syscall.SIGTERM})
+
+	term.Add("app1", func(ctx context.Context) error {
+		fmt.Println("closed app1")
+		return nil
+	})
+
+	term.SetCallback(func(result terminator.TerminationResult) {
+		fmt.Println("received", result.Signal)
+	})
+
+	SignalReady()
+
+	if !term.Wait(5 * time.Second) {
+		t.Fatal("Wait timed out")
+	}
+}
+
+func TestRunWithSignal(t *testing.T) {
+	if IsSignalChild() {
+		t.Skip("already running as a child")
+	}
+
+	for _, sig := range []os.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		result, err := RunWithSignal("TestSignalChild", sig, 10*time.Second)
+		if err != nil {
+			t.Errorf("%v: child run failed: %v\n%s", sig, err, result.Output)
+			continue
+		}
+
+		if result.ExitCode != 0 {
+			t.Errorf("%v: child exited with code %d\n%s", sig, result.ExitCode, result.Output)
+			continue
+		}
+
+		if !strings.Contains(result.Output, "closed app1") {
+			t.Errorf("%v: app1 not closed\n%s", sig, result.Output)
+		}
+
+		if !strings.Contains(result.Output, "received "+sig.String()) {
+			t.Errorf("%v: callback did not receive the signal\n%s", sig, result.Output)
+		}
+	}
+}
+
+func TestRunWithSignalNotReady(t *testing.T) {
+	if IsSignalChild() {
+		t.Skip("already running as a child")
+	}
+
+	_, err := RunWithSignal("TestInjectSignal", syscall.SIGTERM, 10*time.Second)
+	if err != ErrSignalNotReady {
+		t.Errorf("Expected %v for a child never ready, got %v", ErrSignalNotReady, err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package terminatortest provides utilities for testing code that uses the
+// terminator package, without depending on its unexported fields.
+package terminatortest
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/RohanPoojary/go-terminator"
+	"github.com/RohanPoojary/go-terminator/internal/inject"
+)
+
+// InjectSignal delivers sig to term as if it had been received from the
+// operating system, starting the termination process. It reports false if
+// term was not created by the terminator package or a termination signal is
+// already pending.
+func InjectSignal(term terminator.Terminator, sig os.Signal) bool {
+	return inject.Signal(term, sig)
+}
+
+// WaitTimeout simulates Wait timing out now, without waiting: with
+// WithCallbackOnWaitTimeout, the callback receives the partial result. It
+// returns the result Wait would leave behind, and reports false if term was
+// not created by the terminator package or its termination has not started.
+// Combined with a Gate, it deterministically tests a Wait timeout firing
+// while a given closer is running.
+func WaitTimeout(term terminator.Terminator) (terminator.TerminationResult, bool) {
+	if !inject.WaitTimeout(term) {
+		return terminator.TerminationResult{}, false
+	}
+
+	return term.Result()
+}
+
+// FireWatchdog simulates the watchdog set with WithWatchdog firing now,
+// without exiting the process: it returns the exit code the process would
+// exit with, after the watchdog logged the resources being closed and wrote
+// the summary file, if any. It reports false if term was not created by the
+// terminator package, has no watchdog, or its termination is done.
+func FireWatchdog(term terminator.Terminator) (int, bool) {
+	exitCode := 0
+	fired := inject.FireWatchdog(term, func(code int) {
+		exitCode = code
+	})
+
+	return exitCode, fired
+}
+
+// Gate is a closer held until released, to stop the termination at a given
+// resource. It is safe for concurrent use.
+type Gate struct {
+	started     chan struct{}
+	startedOnce sync.Once
+
+	released    chan struct{}
+	releaseOnce sync.Once
+	err         error
+}
+
+// NewGate creates a gate.
+func NewGate() *Gate {
+	return &Gate{
+		started:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+}
+
+// Close is the CloseFunc to register. It returns the error given to Release
+// once released, or the error of ctx once it is done.
+func (g *Gate) Close(ctx context.Context) error {
+	g.startedOnce.Do(func() { close(g.started) })
+
+	select {
+	case <-g.released:
+		return g.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Started returns a channel closed once the termination reached the gate.
+func (g *Gate) Started() <-chan struct{} {
+	return g.started
+}
+
+// Release lets Close return err. Only the first call has an effect.
+func (g *Gate) Release(err error) {
+	g.releaseOnce.Do(func() {
+		g.err = err
+		close(g.released)
+	})
+}
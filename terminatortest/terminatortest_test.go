@@ -0,0 +1,121 @@
+package terminatortest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+func TestInjectSignal(t *testing.T) {
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt})
+
+	closed := false
+	term.Add("app1", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	var received os.Signal
+	term.SetCallback(func(result terminator.TerminationResult) {
+		received = result.Signal
+	})
+
+	if !InjectSignal(term, os.Interrupt) {
+		t.Error("Signal should have been accepted")
+		return
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if !closed {
+		t.Error("app1 not closed")
+	}
+
+	if received != os.Interrupt {
+		t.Error("Callback should receive the injected signal")
+	}
+}
+
+type fakeTerminator struct {
+	terminator.Terminator
+}
+
+func TestInjectSignalForeignTerminator(t *testing.T) {
+	if InjectSignal(fakeTerminator{}, os.Interrupt) {
+		t.Error("Signal shouldn't be accepted by a foreign terminator")
+	}
+}
+
+func TestWaitTimeoutWhileClosing(t *testing.T) {
+	var partial terminator.TerminationResult
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt}, terminator.WithRegistrationGrace(0), terminator.WithCallbackOnWaitTimeout())
+	term.SetCallback(func(result terminator.TerminationResult) {
+		partial = result
+	})
+
+	gate := NewGate()
+	term.Add("db", func(ctx context.Context) error { return nil })
+	term.Add("server", gate.Close)
+	term.Add("cache", func(ctx context.Context) error { return nil })
+
+	InjectSignal(term, os.Interrupt)
+	<-gate.Started()
+
+	result, ok := WaitTimeout(term)
+	if !ok {
+		t.Fatal("Expected a partial result")
+	}
+	if !result.Partial || !partial.Partial {
+		t.Error("Expected the callback to receive the partial result")
+	}
+
+	expected := []terminator.TerminationStatus{terminator.SUCCESS, terminator.PENDING, terminator.PENDING}
+	for index, termData := range result.Result {
+		if termData.Status != expected[index] {
+			t.Errorf("%s: expected %v, got %v", termData.Name, expected[index], termData.Status)
+		}
+	}
+	if running := term.Status().Running; len(running) != 1 || running[0] != "server" {
+		t.Errorf("Expected server to be running, got %v", running)
+	}
+
+	gate.Release(nil)
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+}
+
+func TestFireWatchdog(t *testing.T) {
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt}, terminator.WithRegistrationGrace(0),
+		terminator.WithLogger(discardLogger{}), terminator.WithWatchdog(time.Hour, 3))
+
+	gate := NewGate()
+	term.Add("server", gate.Close)
+
+	InjectSignal(term, os.Interrupt)
+	<-gate.Started()
+
+	if code, fired := FireWatchdog(term); !fired || code != 3 {
+		t.Errorf("Expected the watchdog to exit with code 3, got %d (fired: %v)", code, fired)
+	}
+
+	gate.Release(nil)
+	term.Wait(time.Second)
+
+	if _, fired := FireWatchdog(term); fired {
+		t.Error("The watchdog shouldn't fire once the termination is done")
+	}
+	if _, fired := FireWatchdog(terminator.NewTerminator(nil)); fired {
+		t.Error("The watchdog shouldn't fire without WithWatchdog")
+	}
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Printf(format string, v ...interface{}) {}
@@ -0,0 +1,30 @@
+package terminator
+
+import (
+	"context"
+	"time"
+)
+
+// Ticker runs fn every interval in a managed goroutine until the termination.
+// It is stopped in PhaseBackground, before any other resource is closed,
+// unless another phase is given in the options. The context passed to fn is
+// canceled when the ticker stops, and the closer waits for a running fn to
+// return. It returns the handle of its closer, stopping the ticker when
+// closed, and the error of the registration, see Go.
+func (t *terminator) Ticker(name string, interval time.Duration, fn func(context.Context), opts ...CloserOption) (*Handle, error) {
+	tick := func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				fn(ctx)
+			}
+		}
+	}
+
+	return t.goManaged(name, tick, append([]CloserOption{InPhase(PhaseBackground)}, opts...)...)
+}
@@ -0,0 +1,70 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	var ticks int32
+	term.Ticker("refresh", 10*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&ticks, 1)
+	})
+
+	var ticksAtClose int32
+	term.Add("app1", func(ctx context.Context) error {
+		ticksAtClose = atomic.LoadInt32(&ticks)
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(1 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if ticksAtClose == 0 {
+		t.Error("Ticker should have ticked before the termination")
+	}
+
+	if ticks := atomic.LoadInt32(&ticks); ticks != ticksAtClose {
+		t.Errorf("Ticker should be stopped before app1 is closed, ticked %d times after", ticks-ticksAtClose)
+	}
+}
+
+func TestTickerHandle(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+
+	var ticks int32
+	handle, err := term.Ticker("refresh", 5*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&ticks, 1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := handle.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ticksAtClose := atomic.LoadInt32(&ticks)
+	time.Sleep(30 * time.Millisecond)
+	if ticks := atomic.LoadInt32(&ticks); ticks != ticksAtClose {
+		t.Errorf("Closing the handle should stop the ticker, ticked %d times after", ticks-ticksAtClose)
+	}
+
+	if registered := term.Status().Registered; registered != 0 {
+		t.Errorf("Expected the ticker to be removed, got %d registered resources", registered)
+	}
+}
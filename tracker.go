@@ -0,0 +1,84 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracker tracks in-flight units of work, such as handled messages or
+// requests, so they can be drained during the termination.
+// The zero value is not usable, create one with NewTracker.
+type Tracker struct {
+	mu       sync.Mutex
+	active   int
+	draining bool
+
+	// idle is closed whenever no work is in flight.
+	idle chan struct{}
+}
+
+// NewTracker creates a new Tracker with no work in flight.
+func NewTracker() *Tracker {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &Tracker{idle: idle}
+}
+
+// Begin marks the start of a unit of work, which must be ended with Done.
+// It returns false without tracking anything once the tracker is draining,
+// in which case the work should not be started.
+func (tr *Tracker) Begin() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.draining {
+		return false
+	}
+
+	if tr.active == 0 {
+		tr.idle = make(chan struct{})
+	}
+	tr.active++
+
+	return true
+}
+
+// Done marks the end of a unit of work started with Begin.
+func (tr *Tracker) Done() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == 0 {
+		panic("terminator: Tracker.Done called without a matching Begin")
+	}
+
+	tr.active--
+	if tr.active == 0 {
+		close(tr.idle)
+	}
+}
+
+// Active returns the number of units of work in flight.
+func (tr *Tracker) Active() int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.active
+}
+
+// Drain stops the tracker from accepting new work and waits until the work
+// in flight is done or ctx is done.
+func (tr *Tracker) Drain(ctx context.Context) error {
+	tr.mu.Lock()
+	tr.draining = true
+	idle := tr.idle
+	tr.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
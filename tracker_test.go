@@ -0,0 +1,45 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTrackerDrain(t *testing.T) {
+	tracker := NewTracker()
+
+	if !tracker.Begin() {
+		t.Error("Begin should succeed before draining")
+		return
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		tracker.Done()
+	}()
+
+	if err := tracker.Drain(context.Background()); err != nil {
+		t.Errorf("Drain failed: %v", err)
+	}
+
+	if tracker.Begin() {
+		t.Error("Begin should fail once draining")
+	}
+
+	if tracker.Active() != 0 {
+		t.Errorf("Expected no active work, got %d", tracker.Active())
+	}
+}
+
+func TestTrackerDrainTimeout(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Begin()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := tracker.Drain(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected drain to time out, got %v", err)
+	}
+}
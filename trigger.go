@@ -0,0 +1,136 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// Reasons reported in TerminationResult.Reason.
+const (
+
+	// ReasonSignal is the reason of terminations triggered by a signal.
+	ReasonSignal = "signal"
+
+	// ReasonInternalFailure is the reason of terminations triggered by a
+	// failing managed goroutine, see WithPanicPolicy.
+	ReasonInternalFailure = "internal-failure"
+
+	// ReasonRoutineError is the reason of terminations triggered by the first
+	// error returned by a managed goroutine, see WithTerminateOnError.
+	ReasonRoutineError = "routine-error"
+
+	// ReasonServiceControl is the reason of terminations triggered by a
+	// control request of the service manager, see the winsvc package.
+	ReasonServiceControl = "service-control"
+
+	// ReasonProgrammatic is the reason of terminations triggered by Terminate
+	// without a reason.
+	ReasonProgrammatic = "programmatic"
+)
+
+// trigger describes what started the termination process.
+type trigger struct {
+	signal os.Signal
+	reason string
+	cause  error
+
+	// ctx bounds the termination, nil for context.Background.
+	ctx context.Context
+
+	// deferrable is set for non-urgent terminations, which wait for the
+	// quiesce window, see WithQuiesceWindow.
+	deferrable bool
+
+	// programmatic is set for terminations triggered with Terminate or
+	// TerminateWhenQuiet.
+	programmatic bool
+}
+
+// Terminate triggers the same graceful termination as a signal from code,
+// such as on a fatal internal error or from an admin RPC. The reason is
+// reported in TerminationResult.Reason, ReasonProgrammatic if empty, and
+// TerminationResult.Programmatic is set.
+// It reports false if the termination was already triggered.
+func (t *terminator) Terminate(reason string) bool {
+	if reason == "" {
+		reason = ReasonProgrammatic
+	}
+
+	return t.fire(trigger{reason: reason, programmatic: true})
+}
+
+// TerminateWhenQuiet triggers the termination as Terminate does, but as a
+// non-urgent one: with WithQuiesceWindow, it is deferred to the next
+// low-traffic window, such as for a planned restart requested from an admin
+// RPC. A signal or any urgent trigger received meanwhile still starts the
+// termination right away.
+func (t *terminator) TerminateWhenQuiet(reason string) bool {
+	if reason == "" {
+		reason = ReasonProgrammatic
+	}
+
+	return t.fire(trigger{reason: reason, programmatic: true, deferrable: true})
+}
+
+// terminate starts the termination process without a signal. It reports
+// false if the termination was already triggered.
+func (t *terminator) terminate(reason string, cause error) bool {
+	return t.fire(trigger{reason: reason, cause: cause})
+}
+
+// fire hands the trigger to the monitor. It reports false if the
+// termination was already triggered.
+func (t *terminator) fire(trig trigger) bool {
+	t.ensureMonitor()
+
+	if t.State() != StateIdle {
+		return false
+	}
+
+	select {
+	case t.triggerChan <- trig:
+		return true
+	default:
+		return false
+	}
+}
+
+// PanicPolicy defines how a failure of a goroutine started with Go is handled.
+type PanicPolicy int
+
+const (
+
+	// PanicCrash lets a panic crash the process, as for any goroutine.
+	// Errors returned by the goroutine are only reported in its result data.
+	PanicCrash PanicPolicy = iota
+
+	// PanicTerminate recovers the panic and triggers the graceful termination
+	// with ReasonInternalFailure. So does an error wrapped with Fatal.
+	PanicTerminate
+)
+
+// fatalError marks an error returned by a managed goroutine as fatal.
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string { return e.err.Error() }
+func (e *fatalError) Unwrap() error { return e.err }
+
+// Fatal marks err, returned by a goroutine started with Go, as fatal: with
+// the PanicTerminate policy it triggers the graceful termination of the
+// process. It returns nil if err is nil.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fatalError{err: err}
+}
+
+// IsFatal reports whether err was marked with Fatal.
+func IsFatal(err error) bool {
+	var fatal *fatalError
+	return errors.As(err, &fatal)
+}
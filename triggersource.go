@@ -0,0 +1,84 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// ReasonTriggerSource is the reason of terminations started by a TriggerSource.
+const ReasonTriggerSource = "trigger-source"
+
+// TriggerSource starts the termination from outside of the process without
+// a signal, see WithTriggerSource.
+type TriggerSource interface {
+
+	// Wait blocks until the termination should start, returning nil, or
+	// until ctx is canceled. Any other error is logged and disables the source.
+	Wait(ctx context.Context) error
+}
+
+// WithTriggerSource starts the termination with ReasonTriggerSource once the
+// source fires. Sources are waited on from the creation of the terminator
+// until the termination starts, whatever triggered it. Their terminations
+// are not urgent and wait for the quiesce window, see WithQuiesceWindow.
+func WithTriggerSource(source TriggerSource) Option {
+	return func(c *config) {
+		c.triggerSources = append(c.triggerSources, source)
+	}
+}
+
+// startTriggerSources waits on the trigger sources in the background and
+// returns a function stopping them.
+func (t *terminator) startTriggerSources() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for _, source := range t.config.triggerSources {
+		go func(source TriggerSource) {
+			err := source.Wait(ctx)
+			switch {
+			case ctx.Err() != nil:
+			case err != nil:
+				t.config.logger.Printf("trigger source %T disabled: %v", source, err)
+			default:
+				t.fire(trigger{reason: ReasonTriggerSource, deferrable: true})
+			}
+		}(source)
+	}
+
+	return cancel
+}
+
+// FileTrigger is a TriggerSource firing once a sentinel file exists, for
+// orchestration or cron setups that can only touch files.
+type FileTrigger struct {
+	path     string
+	interval time.Duration
+}
+
+// NewFileTrigger returns a TriggerSource checking every interval whether the
+// file at path, such as /var/run/app.shutdown, exists. The file is removed
+// once it fired, when permitted, so that a restarted process does not stop
+// right away.
+func NewFileTrigger(path string, interval time.Duration) *FileTrigger {
+	return &FileTrigger{path: path, interval: interval}
+}
+
+// Wait implements TriggerSource.
+func (f *FileTrigger) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := os.Stat(f.path); err == nil {
+			os.Remove(f.path)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTrigger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terminator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sentinel := filepath.Join(dir, "app.shutdown")
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithTriggerSource(NewFileTrigger(sentinel, 10*time.Millisecond)))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if term.Wait(50 * time.Millisecond) {
+		t.Fatal("The termination shouldn't start before the sentinel exists")
+	}
+
+	if err := ioutil.WriteFile(sentinel, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The sentinel should start the termination")
+	}
+
+	result, _ := term.Result()
+	if result.Reason != ReasonTriggerSource || result.Signal != nil {
+		t.Errorf("Expected reason %q, got %+v", ReasonTriggerSource, result)
+	}
+
+	if _, err := os.Stat(sentinel); !os.IsNotExist(err) {
+		t.Errorf("Expected the sentinel to be removed, got %v", err)
+	}
+}
+
+type failingSource struct{}
+
+func (failingSource) Wait(ctx context.Context) error { return errors.New("unavailable") }
+
+func TestFailingTriggerSource(t *testing.T) {
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithTriggerSource(failingSource{}))
+	term.Add("db", func(ctx context.Context) error { return nil })
+
+	if term.Wait(50 * time.Millisecond) {
+		t.Fatal("A failing source shouldn't start the termination")
+	}
+	if term.IsShuttingDown() {
+		t.Error("A failing source shouldn't start the termination")
+	}
+}
@@ -0,0 +1,77 @@
+// Package tty restores the terminal of CLI tools on shutdown. The state of
+// the terminal is captured at startup and restored by a finalizer, so that
+// a tool interrupted with Ctrl-C while in raw mode or with a hidden cursor
+// does not leave the terminal of the user broken.
+package tty
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// ErrUnsupported is returned by Capture on platforms where the terminal
+// state cannot be captured.
+var ErrUnsupported = errors.New("tty: terminal state not supported on this platform")
+
+// showCursor is the escape sequence making the cursor visible.
+const showCursor = "\x1b[?25h"
+
+// State is the captured state of a terminal.
+type State struct {
+	file  *os.File
+	state *state
+}
+
+// Capture captures the state of the terminal f, such as os.Stdin, before the
+// tool changes it. It returns an error if f is not a terminal.
+func Capture(f *os.File) (*State, error) {
+	state, err := getState(int(f.Fd()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &State{file: f, state: state}, nil
+}
+
+// Restore restores the captured state of the terminal, leaving raw mode,
+// and makes the cursor visible again. It can be registered as a closer.
+func (s *State) Restore(ctx context.Context) error {
+	err := setState(int(s.file.Fd()), s.state)
+
+	if out := cursorOutput(s.file); out != nil {
+		io.WriteString(out, showCursor)
+	}
+
+	return err
+}
+
+// cursorOutput returns the output showing the cursor of the terminal f: f
+// itself unless it is os.Stdin, in which case it is os.Stdout.
+func cursorOutput(f *os.File) io.Writer {
+	if f == os.Stdin {
+		return os.Stdout
+	}
+
+	return f
+}
+
+// Register captures the state of the terminal f and registers its
+// restoration as a finalizer of term, so that it is restored even when the
+// termination is aborted. It returns an error, registering nothing, if f is
+// not a terminal, such as when the output is redirected.
+func Register(term terminator.Terminator, f *os.File) (*State, error) {
+	state, err := Capture(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := term.AddWithOptions("terminal restore", state.Restore, terminator.InPhase(terminator.PhaseFinalizer)); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
@@ -0,0 +1,59 @@
+package tty
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY opens a pseudo-terminal and returns its slave side.
+func openPTY(t *testing.T) *os.File {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	t.Cleanup(func() { master.Close() })
+
+	if err := unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Skipf("cannot unlock the pseudo-terminal: %v", err)
+	}
+	n, err := unix.IoctlGetInt(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Skipf("cannot name the pseudo-terminal: %v", err)
+	}
+
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("cannot open the pseudo-terminal: %v", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+
+	return slave
+}
+
+func TestRestore(t *testing.T) {
+	pty := openPTY(t)
+
+	state, err := Capture(pty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := unix.IoctlGetTermios(int(pty.Fd()), ioctlReadTermios)
+	raw.Lflag &^= unix.ECHO | unix.ICANON
+	if err := unix.IoctlSetTermios(int(pty.Fd()), ioctlWriteTermios, raw); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := state.Restore(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, _ := unix.IoctlGetTermios(int(pty.Fd()), ioctlReadTermios)
+	if restored.Lflag&(unix.ECHO|unix.ICANON) != unix.ECHO|unix.ICANON {
+		t.Errorf("Expected echo and canonical mode to be restored, got lflag %#x", restored.Lflag)
+	}
+}
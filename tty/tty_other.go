@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package tty
+
+type state struct{}
+
+func getState(fd int) (*state, error) {
+	return nil, ErrUnsupported
+}
+
+func setState(fd int, s *state) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,27 @@
+package tty
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+func TestRegisterNotTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "tty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt})
+	if state, err := Register(term, f); err == nil || state != nil {
+		t.Error("Expected an error for a regular file")
+	}
+
+	if report := term.DryRun(terminator.Simulation{}); len(report.Entries) != 0 {
+		t.Errorf("Expected nothing to be registered, got %+v", report.Entries)
+	}
+}
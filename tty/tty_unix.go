@@ -0,0 +1,25 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package tty
+
+import "golang.org/x/sys/unix"
+
+// state is the termios of the terminal.
+type state struct {
+	termios unix.Termios
+}
+
+func getState(fd int) (*state, error) {
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state{termios: *termios}, nil
+}
+
+func setState(fd int, s *state) error {
+	termios := s.termios
+	return unix.IoctlSetTermios(fd, ioctlWriteTermios, &termios)
+}
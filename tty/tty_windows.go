@@ -0,0 +1,21 @@
+package tty
+
+import "golang.org/x/sys/windows"
+
+// state is the console mode of the terminal.
+type state struct {
+	mode uint32
+}
+
+func getState(fd int) (*state, error) {
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return nil, err
+	}
+
+	return &state{mode: mode}, nil
+}
+
+func setState(fd int, s *state) error {
+	return windows.SetConsoleMode(windows.Handle(fd), s.mode)
+}
@@ -2,22 +2,90 @@ package terminator
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"strconv"
 	"time"
 )
 
 // TerminationStatus represents the status of termination (success or failure).
-type TerminationStatus string
+// The zero value is not a valid status. Statuses are marshaled to their name,
+// so that the serialized results don't depend on the order of the constants.
+type TerminationStatus int
 
 const (
 
 	// SUCCESS indicates that the resource was closed successfully.
-	SUCCESS TerminationStatus = "SUCCESS"
+	SUCCESS TerminationStatus = iota + 1
 
 	// FAILED indicates that the resource failed to close.
-	FAILED TerminationStatus = "FAILED"
+	FAILED
+
+	// PENDING indicates that the resource was not closed yet when the result was taken.
+	PENDING
+
+	// ABORTED indicates that the resource failed to close once the termination was aborted.
+	ABORTED
+
+	// SKIPPED indicates that the resource was not closed because the termination was aborted.
+	SKIPPED
 )
 
+// statusNames maps every status to its name.
+var statusNames = map[TerminationStatus]string{
+	SUCCESS: "SUCCESS",
+	FAILED:  "FAILED",
+	PENDING: "PENDING",
+	ABORTED: "ABORTED",
+	SKIPPED: "SKIPPED",
+}
+
+// TerminationStatuses returns every status, in the order of their values, for
+// code that must handle all of them, such as a table of the statuses or a test
+// checking that a switch is exhaustive.
+func TerminationStatuses() []TerminationStatus {
+	return []TerminationStatus{SUCCESS, FAILED, PENDING, ABORTED, SKIPPED}
+}
+
+// String returns the name of the status.
+func (s TerminationStatus) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+
+	return "TerminationStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// IsTerminal reports whether the resource is done with, that is whether the
+// status is any but PENDING.
+func (s TerminationStatus) IsTerminal() bool {
+	_, ok := statusNames[s]
+	return ok && s != PENDING
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the name of the status.
+func (s TerminationStatus) MarshalText() ([]byte, error) {
+	if _, ok := statusNames[s]; !ok {
+		return nil, fmt.Errorf("terminator: invalid termination status %d", int(s))
+	}
+
+	return []byte(statusNames[s]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the name of a status.
+func (s *TerminationStatus) UnmarshalText(text []byte) error {
+	for status, name := range statusNames {
+		if name == string(text) {
+			*s = status
+			return nil
+		}
+	}
+
+	return fmt.Errorf("terminator: unknown termination status %q", text)
+}
+
 // TerminationResultData holds information about the result of terminating a resource.
 type TerminationResultData struct {
 
@@ -27,38 +95,240 @@ type TerminationResultData struct {
 	// Error that occurred during termination, if any
 	Error error
 
+	// Kind of the error, ErrorKindNone if there was no error
+	Kind ErrorKind
+
 	// Termination status of the process
 	Status TerminationStatus
+
+	// Number of steps run, for resources registered with AddSteps
+	Steps int
+
+	// Time taken to close the resource
+	Duration time.Duration
+
+	// Effective timeout the resource was closed with, zero if none, see WithBeforeEach
+	Timeout time.Duration
+
+	// Resources consumed while closing, set when WithResourceAccounting is used
+	Usage *ResourceUsage
+
+	// Detached is set for closers run outside of the shutdown budget, see WithDetachedContext
+	Detached bool
+
+	// Internal breakdown of composite closers, such as child terminators, see ReportSubResult
+	SubResults []TerminationResultData
+
+	// Retried is set when the resource was closed again by RetryFailed
+	Retried bool
 }
 
 // TerminationResult contains the overall result of the termination process.
 type TerminationResult struct {
 
-	// Termination signal received
+	// Termination signal received, nil if the termination was not triggered by a signal
 	Signal os.Signal
 
+	// Reason the termination was triggered, ReasonSignal for signals
+	Reason string
+
+	// Programmatic is set when the termination was triggered with Terminate or TerminateWhenQuiet
+	Programmatic bool
+
 	// Number of resources that failed or timed out
 	FailedOrTimeoutCount int
 
 	// Result data for each terminated resource
 	Result []TerminationResultData
+
+	// Aborted is set when the termination was abandoned with Abort
+	Aborted bool
+
+	// SLO compares the duration of the termination with its objectives, set once it completed
+	SLO SLOReport
+
+	// Hooks that did not return within the hook timeout, such as HookCallback, see WithHookTimeout
+	HungHooks []string
+
+	// Time from the termination signal until the announcers completed, such as the
+	// deregistration from a service registry, set once the termination completed.
+	// It is zero without announcers, see WithAnnouncer.
+	AnnounceLatency time.Duration
+
+	// ExceededBudget is set when the termination took longer than its budgets, by
+	// BudgetOverrun: the shutdown budget for closing the resources, see
+	// WithShutdownBudget, and the objective for the whole termination, see WithSLO,
+	// whichever it overran most. Both are set once the termination completed, and
+	// the overruns of the phase budgets are reported in SLO.Phases.
+	ExceededBudget bool
+	BudgetOverrun  time.Duration
+
+	// Partial is set when the termination process had not completed when the result was taken.
+	// Resources not closed yet are reported with the PENDING status, unless WithResultSummary is used.
+	Partial bool
+
+	// Summary aggregates the results when WithResultSummary is used, nil otherwise.
+	// Result then only holds the resources that failed or timed out.
+	Summary *ResultSummary
+
+	// cause is the error that triggered the termination, if any.
+	cause error
+
+	// closed is the number of resources closed so far, and indexes the
+	// position in execution order of every entry of Result, nil when Result
+	// holds every resource.
+	closed  int
+	indexes []int
+
+	// term and closers, in execution order, let RetryFailed close the resources again.
+	term    *terminator
+	closers []payload
+}
+
+// Err returns the error that triggered the termination, such as the panic
+// of a managed goroutine. It returns nil for terminations triggered by a signal.
+func (r TerminationResult) Err() error {
+	return r.cause
 }
 
 // CloseFunc defines the function signature for closing a resource.
 type CloseFunc func(context.Context) error
 
 // Terminator is the interface that provides methods for managing resource termination.
+// Its methods are safe for concurrent use.
 type Terminator interface {
 
 	// Add registers a resource to be closed without a timeout.
-	Add(name string, close CloseFunc)
+	// The returned handle closes or reopens the resource at runtime.
+	// It returns ErrSealed once the registrations are sealed, see Seal, or
+	// ErrClosedOnRegistration, see WithCloseLateRegistrations.
+	Add(name string, close CloseFunc) (*Handle, error)
 
 	// AddWithTimeout registers a resource to be closed with a specified timeout.
-	AddWithTimeout(name string, close CloseFunc, timeout time.Duration)
+	AddWithTimeout(name string, close CloseFunc, timeout time.Duration) (*Handle, error)
+
+	// AddWithOptions registers a resource to be closed as configured by the options.
+	AddWithOptions(name string, close CloseFunc, opts ...CloserOption) (*Handle, error)
+
+	// AddWithPriority registers a resource closed before the resources of lower priority.
+	AddWithPriority(name string, close CloseFunc, priority int) (*Handle, error)
+
+	// Seal freezes the registrations: resources registered from then on are
+	// rejected with ErrSealed, as they would never be closed. Registrations
+	// are sealed on their own once the termination starts closing the resources.
+	Seal()
+
+	// AddSteps registers a resource closed by calling step repeatedly until it reports done.
+	AddSteps(name string, step StepFunc, opts ...CloserOption) (*Handle, error)
+
+	// Go runs fn in a managed goroutine whose context is canceled when its closer runs.
+	Go(name string, fn func(context.Context) error, opts ...CloserOption) error
+
+	// Group registers a group of managed goroutines sharing a context canceled on their first error or by the termination.
+	Group(name string, opts ...CloserOption) (*Group, error)
+
+	// Ticker runs fn every interval until it is stopped, first thing during the termination.
+	Ticker(name string, interval time.Duration, fn func(context.Context), opts ...CloserOption) (*Handle, error)
+
+	// CloseNow closes a registered resource immediately and removes it from the stack.
+	CloseNow(ctx context.Context, name string) TerminationResultData
+
+	// RemoveMatching removes the resources whose hierarchical name matches the glob pattern, see MatchName.
+	RemoveMatching(pattern string) (int, error)
+
+	// CloseMatching closes the resources whose hierarchical name matches the glob pattern now, see MatchName.
+	CloseMatching(ctx context.Context, pattern string) ([]TerminationResultData, error)
+
+	// Child returns a terminator closed as a single resource, within the remaining budget of its closer.
+	Child(name string, opts ...CloserOption) (Terminator, error)
+
+	// AddScoped registers a short-lived resource and returns the function removing it,
+	// warning about the ones garbage collected without being released.
+	AddScoped(name string, close CloseFunc, opts ...CloserOption) (release func(), err error)
+
+	// AddCommand registers an external hook command, killed once the timeout of the closer is exceeded.
+	AddCommand(name string, cmd *exec.Cmd, opts ...CloserOption) (*Handle, error)
+
+	// AddScript registers the script at path, run with args when the resource is closed.
+	AddScript(name, path string, args ...string) (*Handle, error)
+
+	// AddWatcher runs a configuration or file watcher as a managed goroutine stopped first thing during the termination.
+	AddWatcher(name string, watcher io.Closer, run func(ctx context.Context) error, opts ...CloserOption) (*Handle, error)
+
+	// LongPolls registers a registry of long-poll handlers told to respond as the termination starts.
+	LongPolls(name string, opts ...CloserOption) (*LongPolls, error)
+
+	// JobBoundary registers a resource telling batch workers to exit between two items, closed once they did.
+	JobBoundary(name string, opts ...CloserOption) (*JobBoundary, error)
+
+	// Barrier registers a resource closed once n participants called Arrive on the returned barrier.
+	Barrier(name string, n int, opts ...CloserOption) (*Barrier, error)
 
-	// SetCallback sets the callback function to be executed after all resources are closed.
+	// Start starts the components and registers their stop hooks, stopping the ones
+	// started in reverse order if one fails to start.
+	Start(ctx context.Context, components ...Component) error
+
+	// SetCallback sets the callback function to be executed after all resources are closed,
+	// before Wait returns. Wait called while the callback runs waits for the resources to be closed only.
 	SetCallback(callback func(TerminationResult))
 
-	// Wait waits for the termination process to complete within the specified timeout duration.
+	// Wait waits for the termination process to complete within the specified timeout duration,
+	// until it completed with a zero timeout, and without waiting with a negative one.
 	Wait(timeout time.Duration) bool
+
+	// WaitErr waits like Wait, returning nil, ErrWaitTimeout or ErrAborted.
+	WaitErr(timeout time.Duration) error
+
+	// Ready marks the application startup as complete.
+	Ready()
+
+	// IsReady reports whether Ready was called and the termination process has not started yet.
+	IsReady() bool
+
+	// DryRun projects the timeline of the termination with simulated durations, without closing anything.
+	DryRun(sim Simulation) DryRunReport
+
+	// Rehearse runs the rehearsal functions of the resources registered WithRehearsal, without closing anything.
+	Rehearse(ctx context.Context) TerminationResult
+
+	// Pause halts the termination between two closers until Resume is called, for live debugging.
+	Pause() bool
+
+	// Resume resumes the termination paused by Pause.
+	Resume() bool
+
+	// Terminate triggers the graceful termination from code, without a signal.
+	Terminate(reason string) bool
+
+	// TerminateWhenQuiet triggers the graceful termination from code once the quiesce window opens, see WithQuiesceWindow.
+	TerminateWhenQuiet(reason string) bool
+
+	// Abort abandons the termination in progress, skipping the resources not closed yet except for finalizers.
+	Abort() bool
+
+	// SelfTest verifies that the signals reach the terminator, without triggering the termination.
+	SelfTest(timeout time.Duration) SelfTestReport
+
+	// IsShuttingDown reports whether the termination process has started.
+	IsShuttingDown() bool
+
+	// RequestContext returns a context for in-flight work derived from parent,
+	// canceled once the request grace period elapsed after the termination started.
+	RequestContext(parent context.Context) context.Context
+
+	// Draining returns a channel closed once the termination starts, telling the work in flight to finish up.
+	Draining() <-chan struct{}
+
+	// PhaseContext returns a context derived from parent, canceled just before the resources of the phase are closed.
+	PhaseContext(parent context.Context, phase Phase) context.Context
+
+	// State returns the current state of the terminator lifecycle.
+	State() State
+
+	// Status returns a snapshot of the termination process, including its overall progress.
+	Status() Status
+
+	// Result returns the result of the termination process, which is partial while it is still running.
+	// It returns false if the termination process has not started.
+	Result() (TerminationResult, bool)
 }
@@ -0,0 +1,73 @@
+package terminator
+
+import (
+	"context"
+	"sync"
+)
+
+// ValueKey identifies a value passed from a closer to the closers run after
+// it, such as the number of connections drained by the HTTP server, included
+// by the metrics flusher in its final emission. Keys are compared by
+// identity, so the closers sharing a value share its key, typically a
+// package-level variable, and the type of the value is part of its contract.
+type ValueKey struct {
+	name string
+}
+
+// NewValueKey returns a key for the values named name, used in messages only.
+func NewValueKey(name string) *ValueKey {
+	return &ValueKey{name: name}
+}
+
+// String returns the name of the key.
+func (k *ValueKey) String() string {
+	return k.name
+}
+
+// valueStore holds the values published during a termination or a rehearsal.
+type valueStore struct {
+	mu     sync.Mutex
+	values map[*ValueKey]interface{}
+}
+
+// valueStoreKey is the context key of the valueStore of a run.
+type valueStoreKey struct{}
+
+// withValueStore returns a context carrying a new valueStore, shared by the
+// closers of a termination or a rehearsal.
+func withValueStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, valueStoreKey{}, &valueStore{values: make(map[*ValueKey]interface{})})
+}
+
+// Publish stores value under key for the closers run later during the same
+// termination, or rehearsal, replacing the value published before if any.
+// It is meant to be called from within a CloseFunc, and does nothing when
+// ctx does not belong to a closer run by a termination or a rehearsal, such
+// as with CloseNow. Closers run concurrently in a parallel phase may not see
+// the values they publish to each other.
+func Publish(ctx context.Context, key *ValueKey, value interface{}) {
+	store, _ := ctx.Value(valueStoreKey{}).(*valueStore)
+	if store == nil {
+		return
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.values[key] = value
+}
+
+// Lookup returns the value published under key by a closer run earlier
+// during the same termination, or rehearsal, and whether there was one.
+func Lookup(ctx context.Context, key *ValueKey) (interface{}, bool) {
+	store, _ := ctx.Value(valueStoreKey{}).(*valueStore)
+	if store == nil {
+		return nil, false
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	value, ok := store.values[key]
+	return value, ok
+}
@@ -0,0 +1,61 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPublishLookup(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+	drained := NewValueKey("drained_conns")
+
+	var emitted interface{}
+	term.AddWithOptions("metrics", func(ctx context.Context) error {
+		emitted, _ = Lookup(ctx, drained)
+		return nil
+	}, InPhase(PhaseTelemetry))
+	term.AddWithOptions("http server", func(ctx context.Context) error {
+		Publish(ctx, drained, 42)
+		return nil
+	}, InPhase(PhaseServer))
+	term.Add("cache", func(ctx context.Context) error {
+		Publish(ctx, drained, 0)
+		return nil
+	})
+	term.CloseNow(context.Background(), "cache")
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if emitted != 42 {
+		t.Errorf("Expected the value published by the HTTP server, got %v", emitted)
+	}
+	if _, ok := Lookup(context.Background(), drained); ok {
+		t.Error("Expected no value outside of a termination")
+	}
+}
+
+func TestPublishRehearsal(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt})
+	key := NewValueKey("rehearsed")
+
+	var found []bool
+	noop := func(ctx context.Context) error { return nil }
+	term.AddWithOptions("check", noop, InPhase(PhaseTelemetry), WithRehearsal(func(ctx context.Context) error {
+		_, ok := Lookup(ctx, key)
+		found = append(found, ok)
+		Publish(ctx, key, true)
+		return nil
+	}))
+
+	term.Rehearse(context.Background())
+	term.Rehearse(context.Background())
+
+	if len(found) != 2 || found[0] || found[1] {
+		t.Errorf("Expected every rehearsal to start with no value, got %v", found)
+	}
+}
@@ -0,0 +1,116 @@
+// Package wal closes append-only log files, such as write-ahead logs,
+// cleanly: Closer syncs the file, appends a clean-close marker record and
+// closes it when the service shuts down, and Open checks the marker at
+// startup to tell whether the log needs recovery.
+//
+// A log not ending with the marker was not closed cleanly, for instance
+// after a crash or a kill at the end of the grace period, so its last
+// records may be torn.
+package wal
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// DefaultMarker is the clean-close marker record used with a nil marker.
+var DefaultMarker = []byte("\x00terminator:clean-close\x00\n")
+
+// Closer returns a closer syncing file, appending marker, DefaultMarker if
+// nil, syncing again and closing the file. The writers of the log must have
+// stopped before it runs, so it is usually registered in PhaseStorage.
+// The file is closed even when a step fails or the context is done, in
+// which case the marker is not appended and the log is recovered at the
+// next startup.
+func Closer(file *os.File, marker []byte) terminator.CloseFunc {
+	if marker == nil {
+		marker = DefaultMarker
+	}
+
+	return func(ctx context.Context) error {
+		err := markClean(ctx, file, marker)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+
+		return err
+	}
+}
+
+// markClean syncs the records of the file before appending the marker, so
+// that the marker never makes it to disk before them.
+func markClean(ctx context.Context, file *os.File, marker []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := file.Write(marker); err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+// Open opens the log at path for appending, creating it if needed, and
+// reports whether it was closed cleanly by Closer with marker, DefaultMarker
+// if nil. A new or empty log is clean. The marker of a clean log is removed,
+// so that the next records follow the last one and a crash before the next
+// clean close is detected. An unclean log is returned as is, for the
+// application to recover or repair it.
+func Open(path string, marker []byte) (file *os.File, clean bool, err error) {
+	if marker == nil {
+		marker = DefaultMarker
+	}
+
+	file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, false, err
+	}
+
+	clean, err = unmarkClean(file, marker)
+	if err != nil {
+		file.Close()
+		return nil, false, err
+	}
+
+	return file, clean, nil
+}
+
+// unmarkClean reports whether the file ends with the marker, removing it.
+func unmarkClean(file *os.File, marker []byte) (bool, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	size := info.Size()
+	if size == 0 {
+		return true, nil
+	}
+	if size < int64(len(marker)) {
+		return false, nil
+	}
+
+	tail := make([]byte, len(marker))
+	if _, err := file.ReadAt(tail, size-int64(len(marker))); err != nil {
+		return false, err
+	}
+	if !bytes.Equal(tail, marker) {
+		return false, nil
+	}
+
+	if err := file.Truncate(size - int64(len(marker))); err != nil {
+		return false, err
+	}
+
+	return true, file.Sync()
+}
@@ -0,0 +1,75 @@
+package wal
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+
+	file, clean, err := Open(path, nil)
+	if err != nil || !clean {
+		t.Fatalf("Expected a new log to be clean, got %v, %v", clean, err)
+	}
+	file.WriteString("record 1\n")
+	if err := Closer(file, nil)(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	file, clean, err = Open(path, nil)
+	if err != nil || !clean {
+		t.Fatalf("Expected the log closed by Closer to be clean, got %v, %v", clean, err)
+	}
+	file.WriteString("record 2\n")
+	file.Close()
+
+	data, _ := ioutil.ReadFile(path)
+	if string(data) != "record 1\nrecord 2\n" {
+		t.Errorf("Expected the marker to be removed on open, got %q", data)
+	}
+
+	file, clean, err = Open(path, nil)
+	if err != nil || clean {
+		t.Fatalf("Expected the log closed without Closer to need recovery, got %v, %v", clean, err)
+	}
+	file.Close()
+}
+
+func TestCloseWithContextDone(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+
+	file, _, err := Open(path, []byte("END\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("record\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Closer(file, []byte("END\n"))(ctx); err != context.Canceled {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+	if _, err := file.WriteString("late"); err == nil {
+		t.Error("Expected the file to be closed")
+	}
+
+	file, clean, err := Open(path, []byte("END\n"))
+	if err != nil || clean {
+		t.Errorf("Expected the log to need recovery, got %v, %v", clean, err)
+	}
+	file.Close()
+}
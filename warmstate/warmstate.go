@@ -0,0 +1,93 @@
+// Package warmstate persists in-memory state, such as a cache, across
+// restarts: Saver writes a snapshot of the state to disk when the service
+// shuts down, and Load restores it at startup.
+//
+// Snapshots are encoded as JSON, bounded in size, and written within the
+// timeout of the closer to a temporary file renamed once complete, so that a
+// snapshot cut short never replaces a complete one.
+package warmstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/RohanPoojary/go-terminator"
+)
+
+// ErrTooLarge is returned by the closer of Saver when the snapshot exceeds
+// its maximum size. The previous snapshot, if any, is left untouched.
+var ErrTooLarge = errors.New("warmstate: snapshot too large")
+
+// Saver returns a closer writing the state returned by snapshot to path as
+// JSON, aborted with ErrTooLarge beyond maxBytes, or with the error of the
+// context once it is done. A maxBytes of zero or less is unbounded.
+// snapshot is called once the closer runs and must not be modified while it
+// is encoded, so it should return a copy or the state once writes stopped.
+func Saver(path string, maxBytes int64, snapshot func() interface{}) terminator.CloseFunc {
+	return func(ctx context.Context) error {
+		file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(file.Name())
+
+		w := &boundedWriter{ctx: ctx, file: file, remaining: maxBytes}
+		err = json.NewEncoder(w).Encode(snapshot())
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		return os.Rename(file.Name(), path)
+	}
+}
+
+// Load restores the snapshot at path into v, which is decoded as with
+// json.Unmarshal, and reports whether there was one. The snapshot is removed
+// once read, so that it is never restored twice, such as after a crash.
+func Load(path string, v interface{}) (bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// boundedWriter writes to a file until its size or the context is exceeded.
+type boundedWriter struct {
+	ctx       context.Context
+	file      *os.File
+	remaining int64
+}
+
+// Write implements io.Writer.
+func (w *boundedWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if w.remaining > 0 {
+		if int64(len(p)) > w.remaining {
+			return 0, ErrTooLarge
+		}
+		w.remaining -= int64(len(p))
+	}
+
+	return w.file.Write(p)
+}
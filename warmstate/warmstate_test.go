@@ -0,0 +1,72 @@
+package warmstate
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "warmstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache.json")
+
+	state := map[string]int{"a": 1, "b": 2}
+	if err := Saver(path, 0, func() interface{} { return state })(context.Background()); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	var loaded map[string]int
+	ok, err := Load(path, &loaded)
+	if err != nil || !ok {
+		t.Fatalf("load = %v, %v", ok, err)
+	}
+	if !reflect.DeepEqual(loaded, state) {
+		t.Errorf("loaded %v, want %v", loaded, state)
+	}
+
+	if ok, err := Load(path, &loaded); ok || err != nil {
+		t.Errorf("second load = %v, %v, want no snapshot", ok, err)
+	}
+}
+
+func TestSaveBounded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "warmstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache.json")
+
+	if err := Saver(path, 0, func() interface{} { return "previous" })(context.Background()); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	large := make([]int, 1000)
+	if err := Saver(path, 100, func() interface{} { return large })(context.Background()); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("save = %v, want ErrTooLarge", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Saver(path, 0, func() interface{} { return large })(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("save = %v, want context.Canceled", err)
+	}
+
+	var loaded string
+	if ok, err := Load(path, &loaded); !ok || err != nil || loaded != "previous" {
+		t.Errorf("load = %q, %v, %v, want the previous snapshot", loaded, ok, err)
+	}
+
+	entries, _ := ioutil.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("%d files left behind", len(entries))
+	}
+}
@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"strings"
+	"time"
+)
+
+// WithWatchdog exits the process with the given code if the termination has
+// not completed d after the termination signal, logging the resources still
+// being closed. It is a last resort against stuck closers, to exit on the
+// application's own terms before the process is killed.
+func WithWatchdog(d time.Duration, exitCode int) Option {
+	return func(c *config) {
+		c.watchdog = d
+		c.watchdogExitCode = exitCode
+	}
+}
+
+// startWatchdog starts the watchdog, if any, and returns a function stopping it.
+func (t *terminator) startWatchdog() (stop func()) {
+	if t.config.watchdog <= 0 {
+		return func() {}
+	}
+
+	timer := time.AfterFunc(t.config.watchdog, func() {
+		t.fireWatchdog(osExit)
+	})
+
+	return func() { timer.Stop() }
+}
+
+// fireWatchdog exits with the exit code of the watchdog unless the
+// termination is done, and reports whether it did.
+func (t *terminator) fireWatchdog(exit func(code int)) bool {
+	status := t.Status()
+	if status.Done {
+		return false
+	}
+
+	t.config.logger.Printf("watchdog: termination did not complete within %v, exiting with code %d while closing %s",
+		t.config.watchdog, t.config.watchdogExitCode, strings.Join(status.Running, ", "))
+	if result, ok := t.Result(); ok {
+		t.mu.Lock()
+		elapsed := time.Since(t.triggeredAt)
+		t.mu.Unlock()
+		t.writeSummaryFile(result, elapsed, t.config.watchdogExitCode)
+	}
+	exit(t.config.watchdogExitCode)
+
+	return true
+}
@@ -0,0 +1,60 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchdog(t *testing.T) {
+	exitCode := make(chan int, 1)
+	osExit = func(code int) { exitCode <- code }
+	defer func() { osExit = os.Exit }()
+
+	logger := &recordingLogger{}
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger), WithWatchdog(50*time.Millisecond, 4))
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	term.Add("stuck", func(ctx context.Context) error {
+		<-stuck
+		return nil
+	})
+
+	term.(*terminator).signalChan <- os.Interrupt
+
+	select {
+	case code := <-exitCode:
+		if code != 4 {
+			t.Errorf("Expected exit code 4, got %d", code)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("The watchdog should have exited the process")
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "stuck") {
+		t.Errorf("Expected the stuck closer to be logged, got %q", logger.lines)
+	}
+}
+
+func TestWithGracePeriodFromEnv(t *testing.T) {
+	os.Setenv(DefaultGracePeriodEnv, "30")
+	defer os.Unsetenv(DefaultGracePeriodEnv)
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithGracePeriodFromEnv(DefaultGracePeriodEnv))
+	config := term.(*terminator).config
+
+	if config.shutdownBudget != 24*time.Second || config.watchdog != 27*time.Second || config.watchdogExitCode != 1 {
+		t.Errorf("Expected a 24s budget and a 27s watchdog, got %v and %v", config.shutdownBudget, config.watchdog)
+	}
+
+	os.Setenv(DefaultGracePeriodEnv, "soon")
+	logger := &recordingLogger{}
+	term = NewTerminator([]os.Signal{os.Interrupt}, WithLogger(logger), WithGracePeriodFromEnv(DefaultGracePeriodEnv))
+
+	if term.(*terminator).config.shutdownBudget != 0 || len(logger.lines) != 1 {
+		t.Errorf("Expected an invalid grace period to be ignored with a warning, got %q", logger.lines)
+	}
+}
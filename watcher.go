@@ -0,0 +1,54 @@
+package terminator
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// IsShuttingDown reports whether the termination process has started, for
+// configuration watchers and feature flag reloaders to stop applying changes.
+func (t *terminator) IsShuttingDown() bool {
+	return t.State() != StateIdle
+}
+
+// AddWatcher runs a configuration or file watcher, such as an
+// fsnotify.Watcher, as a managed goroutine stopped in PhaseBackground,
+// before any other resource is closed, unless another phase is given in the
+// options. run consumes the events of the watcher until ctx is canceled.
+// The watcher is closed when run returns and as soon as ctx is canceled, so
+// loops reading its event channels exit once they are closed.
+// It returns the handle of its closer, stopping the watcher when closed, and
+// the error of the registration, see Go.
+func (t *terminator) AddWatcher(name string, watcher io.Closer, run func(ctx context.Context) error, opts ...CloserOption) (*Handle, error) {
+	var once sync.Once
+	var closeErr error
+	closeWatcher := func() {
+		once.Do(func() {
+			closeErr = watcher.Close()
+		})
+	}
+
+	watch := func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		defer close(stopped)
+
+		go func() {
+			select {
+			case <-ctx.Done():
+				closeWatcher()
+			case <-stopped:
+			}
+		}()
+
+		err := run(ctx)
+		closeWatcher()
+
+		if err != nil {
+			return err
+		}
+		return closeErr
+	}
+
+	return t.goManaged(name, watch, append([]CloserOption{InPhase(PhaseBackground)}, opts...)...)
+}
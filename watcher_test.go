@@ -0,0 +1,68 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWatcher mimics an fsnotify.Watcher, whose event channel is closed by Close.
+type fakeWatcher struct {
+	events chan string
+	once   sync.Once
+	closed int
+}
+
+func (w *fakeWatcher) Close() error {
+	w.once.Do(func() { close(w.events) })
+	w.closed++
+	return nil
+}
+
+func TestAddWatcher(t *testing.T) {
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0))
+
+	watcher := &fakeWatcher{events: make(chan string, 1)}
+	reloaded := make(chan string, 1)
+	var shuttingDownAtExit bool
+
+	// The loop only reads the events, relying on their channel to be closed.
+	term.AddWatcher("config watcher", watcher, func(ctx context.Context) error {
+		for event := range watcher.events {
+			reloaded <- event
+		}
+		shuttingDownAtExit = term.IsShuttingDown()
+		return nil
+	})
+
+	var order []string
+	term.Add("db", func(ctx context.Context) error {
+		order = append(order, "db")
+		return nil
+	})
+
+	watcher.events <- "config.yaml"
+	if event := <-reloaded; event != "config.yaml" {
+		t.Errorf("Unexpected event %q", event)
+	}
+
+	if term.IsShuttingDown() {
+		t.Error("The terminator shouldn't be shutting down yet")
+	}
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(1 * time.Second) {
+		t.Fatal("The watcher should be closed to stop its loop")
+	}
+
+	if !shuttingDownAtExit || watcher.closed != 1 {
+		t.Errorf("Expected the watcher to be closed once during the termination, got %d closes", watcher.closed)
+	}
+
+	result, _ := term.Result()
+	if result.Result[0].Name != "config watcher" || len(order) != 1 {
+		t.Errorf("Expected the watcher to be stopped first, got %+v", result.Result)
+	}
+}
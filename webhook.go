@@ -0,0 +1,83 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"text/template"
+)
+
+// WebhookEvent is the step of the termination a webhook is notified of.
+type WebhookEvent string
+
+const (
+	// WebhookStarted is notified when the resources start being closed.
+	WebhookStarted WebhookEvent = "started"
+
+	// WebhookCompleted is notified once the termination completed, with its summary.
+	WebhookCompleted WebhookEvent = "completed"
+)
+
+// WebhookPayload is the data sent to a webhook, as JSON or through the
+// template of the webhook, see WithWebhook.
+type WebhookPayload struct {
+	Event WebhookEvent `json:"event"`
+
+	// Host is the host name of the instance terminating
+	Host string `json:"host,omitempty"`
+
+	// Reason the termination was triggered, see TerminationResult.Reason
+	Reason string `json:"reason"`
+
+	// Signal received, empty if the termination was not triggered by a signal
+	Signal string `json:"signal,omitempty"`
+
+	// Summary of the termination, once completed
+	Summary *ExitSummary `json:"summary,omitempty"`
+}
+
+// webhook is an endpoint notified of the termination.
+type webhook struct {
+	url  string
+	body *template.Template
+}
+
+// newWebhookPayload returns the payload of the event for a termination
+// triggered for reason by signal, nil if not by a signal.
+func newWebhookPayload(event WebhookEvent, reason string, signal os.Signal) WebhookPayload {
+	payload := WebhookPayload{Event: event, Reason: reason}
+	payload.Host, _ = os.Hostname()
+	if signal != nil {
+		payload.Signal = signal.String()
+	}
+
+	return payload
+}
+
+// completedWebhookPayload returns the payload notifying the completion of the
+// termination, with the summary of its result.
+func completedWebhookPayload(result TerminationResult) WebhookPayload {
+	payload := newWebhookPayload(WebhookCompleted, result.Reason, result.Signal)
+	summary := newExitSummary(result, result.SLO.Duration, result.ExitCode())
+	payload.Summary = &summary
+
+	return payload
+}
+
+// notifyContext returns the context bounding the notifications of the
+// termination by the remaining shutdown budget and the watchdog, if any.
+func (t *terminator) notifyContext() (context.Context, context.CancelFunc) {
+	ctx, cancelBudget := t.budgetContext(context.Background())
+	if t.config.watchdog <= 0 {
+		return ctx, cancelBudget
+	}
+
+	t.mu.Lock()
+	deadline := t.triggeredAt.Add(t.config.watchdog)
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return ctx, func() {
+		cancel()
+		cancelBudget()
+	}
+}
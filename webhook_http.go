@@ -0,0 +1,154 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const (
+	// webhookAttempts is how many times a webhook is posted to before giving up.
+	webhookAttempts = 3
+
+	// webhookTimeout is how long every attempt has to answer.
+	webhookTimeout = 2 * time.Second
+
+	// webhookBackoff is the delay before the second attempt, doubled afterwards.
+	webhookBackoff = 250 * time.Millisecond
+)
+
+// WithWebhook posts a WebhookPayload to url when the resources start being
+// closed and once the termination completed, with the ExitSummary of the
+// result, for visibility into the terminations without a metrics pipeline.
+// The payload is sent as JSON, or rendered with body when not nil, for
+// instance to format a chat message, with the content type of the extension
+// of the name of the template, such as "slack.json", or else of its output.
+// Every notification is attempted up to 3 times, 2 seconds each, within the
+// remaining shutdown budget and the watchdog, and failures are logged. The
+// completion is notified before Wait returns.
+func WithWebhook(url string, body *template.Template) Option {
+	return func(c *config) {
+		c.webhooks = append(c.webhooks, webhook{url: url, body: body})
+	}
+}
+
+// WebhookReporter reports the completion of the termination to url as
+// WithWebhook does, as a Reporter.
+func WebhookReporter(url string, body *template.Template) Reporter {
+	hook := webhook{url: url, body: body}
+	return ReporterFunc(func(result TerminationResult) error {
+		return hook.notify(context.Background(), completedWebhookPayload(result))
+	})
+}
+
+// notifyWebhooks posts the payload to every webhook concurrently in the
+// background, within ctx, returning a channel closed once done.
+func (t *terminator) notifyWebhooks(ctx context.Context, payload WebhookPayload) <-chan struct{} {
+	done := make(chan struct{})
+	if len(t.config.webhooks) == 0 {
+		close(done)
+		return done
+	}
+
+	var wg sync.WaitGroup
+	for _, hook := range t.config.webhooks {
+		wg.Add(1)
+		go func(hook webhook) {
+			defer wg.Done()
+			if err := hook.notify(ctx, payload); err != nil {
+				t.config.logger.Printf("notifying the webhook %s of the termination %s: %v", hook.url, payload.Event, err)
+			}
+		}(hook)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	return done
+}
+
+// notify posts the payload to the webhook, retrying on failure until ctx is done.
+func (w webhook) notify(ctx context.Context, payload WebhookPayload) error {
+	var body bytes.Buffer
+	if w.body == nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return err
+		}
+	} else if err := w.body.Execute(&body, payload); err != nil {
+		return err
+	}
+	contentType := w.contentType(body.Bytes())
+
+	var err error
+	backoff := webhookBackoff
+	for attempt := 0; attempt < webhookAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+			backoff *= 2
+		}
+
+		if err = w.post(ctx, contentType, body.Bytes()); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// contentType returns the content type of the body rendered by the webhook:
+// JSON without a template, else the type of the extension of the name of the
+// template, or JSON or plain text depending on the body.
+func (w webhook) contentType(body []byte) string {
+	if w.body == nil {
+		return "application/json"
+	}
+	if contentType := mime.TypeByExtension(path.Ext(w.body.Name())); contentType != "" {
+		return contentType
+	}
+	if json.Valid(body) {
+		return "application/json"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+// post posts the body to the webhook once.
+func (w webhook) post(ctx context.Context, contentType string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build terminator_nohttp
+// +build terminator_nohttp
+
+package terminator
+
+import "context"
+
+// notifyWebhooks does nothing without net/http: WithWebhook is not available
+// with the terminator_nohttp build tag.
+func (t *terminator) notifyWebhooks(ctx context.Context, payload WebhookPayload) <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+
+	return done
+}
@@ -0,0 +1,188 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var payloads []WebhookPayload
+	attempts := 0
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Unexpected webhook body: %v", err)
+		}
+		payloads = append(payloads, payload)
+	}))
+	defer hook.Close()
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(make(lineLogger, 8)), WithWebhook(hook.URL, nil))
+	term.Add("db", func(ctx context.Context) error { return errors.New("connection reset") })
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(2 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(payloads) != 2 || payloads[0].Event != WebhookStarted || payloads[1].Event != WebhookCompleted {
+		t.Fatalf("Expected the start and the completion to be notified after a retry, got %+v", payloads)
+	}
+	if payloads[0].Signal != os.Interrupt.String() || payloads[0].Summary != nil {
+		t.Errorf("Unexpected start payload: %+v", payloads[0])
+	}
+	if summary := payloads[1].Summary; summary == nil || summary.ExitCode != 1 || len(summary.Failed) != 1 || summary.Failed[0].Name != "db" {
+		t.Errorf("Expected the summary of the result on completion, got %+v", summary)
+	}
+}
+
+func TestWebhookTemplate(t *testing.T) {
+	bodies := make(chan string, 2)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer hook.Close()
+
+	body := template.Must(template.New("slack").Parse(`{"text":"termination {{.Event}}"}`))
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithWebhook(hook.URL, body))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	for _, expected := range []string{`{"text":"termination started"}`, `{"text":"termination completed"}`} {
+		if got := <-bodies; got != expected {
+			t.Errorf("Expected the body %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer hook.Close()
+
+	logger := make(lineLogger, 8)
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(logger), WithWebhook(hook.URL, nil))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(5 * time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(logger) != 2 {
+		t.Errorf("Expected the two failed notifications to be logged, got %d lines", len(logger))
+	}
+}
+
+func TestWebhookReporter(t *testing.T) {
+	payloads := make(chan WebhookPayload, 2)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads <- payload
+	}))
+	defer hook.Close()
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithReporter(WebhookReporter(hook.URL, nil)))
+
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Termination timed out")
+	}
+
+	if len(payloads) != 1 {
+		t.Fatalf("Expected only the completion to be reported, got %d payloads", len(payloads))
+	}
+	if payload := <-payloads; payload.Event != WebhookCompleted || payload.Summary == nil || payload.Summary.ExitCode != 0 {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhookBoundedByBudget(t *testing.T) {
+	release := make(chan struct{})
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer hook.Close()
+	defer close(release)
+
+	term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithLogger(make(lineLogger, 8)),
+		WithShutdownBudget(100*time.Millisecond), WithWebhook(hook.URL, nil), WithWebhook(hook.URL+"/other", nil))
+
+	start := time.Now()
+	term.(*terminator).signalChan <- os.Interrupt
+	if !term.Wait(time.Second) {
+		t.Fatal("Expected the notifications to be bounded by the shutdown budget")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the termination to complete within the budget, took %v", elapsed)
+	}
+}
+
+func TestWebhookContentType(t *testing.T) {
+	contentTypes := make(chan string, 6)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentTypes <- r.Header.Get("Content-Type")
+	}))
+	defer hook.Close()
+
+	tests := []struct {
+		body     *template.Template
+		expected string
+	}{
+		{nil, "application/json"},
+		{template.Must(template.New("slack").Parse(`{"text":"{{.Event}}"}`)), "application/json"},
+		{template.Must(template.New("message").Parse(`termination {{.Event}}`)), "text/plain; charset=utf-8"},
+	}
+
+	for _, test := range tests {
+		term := NewTerminator([]os.Signal{os.Interrupt}, WithRegistrationGrace(0), WithWebhook(hook.URL, test.body))
+		term.(*terminator).signalChan <- os.Interrupt
+		if !term.Wait(time.Second) {
+			t.Fatal("Termination timed out")
+		}
+
+		for i := 0; i < 2; i++ {
+			if got := <-contentTypes; got != test.expected {
+				t.Errorf("Expected the content type %s, got %s", test.expected, got)
+			}
+		}
+	}
+
+	hookHTML := webhook{url: hook.URL, body: template.Must(template.New("message.html").Parse(`<b>{{.Event}}</b>`))}
+	if got := hookHTML.contentType([]byte("<b>started</b>")); got != "text/html; charset=utf-8" {
+		t.Errorf("Expected the content type of the extension of the template, got %s", got)
+	}
+}
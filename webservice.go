@@ -0,0 +1,44 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"net/http"
+)
+
+// NewWebService creates a terminator wired with the typical shutdown pipeline
+// of an HTTP service: run the readiness hooks, wait for the pre-stop delay,
+// stop accepting connections and drain in-flight requests, close the server,
+// close the resources registered by the application and flush telemetry.
+// It returns the error of the registrations of the pipeline, if any.
+func NewWebService(srv *http.Server, opts ...ServiceOption) (Terminator, error) {
+	config := newServiceConfig(opts)
+	term, err := config.newTerminator()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = term.AddWithOptions("http server", func(ctx context.Context) error {
+		drainCtx, cancel := context.WithTimeout(ctx, config.drainTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(drainCtx); err != nil {
+			// Draining did not complete, close the remaining connections.
+			srv.Close()
+			return err
+		}
+
+		return nil
+	}, InPhase(PhaseServer))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.addClosers(term); err != nil {
+		return nil, err
+	}
+
+	return term, nil
+}
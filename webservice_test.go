@@ -0,0 +1,98 @@
+//go:build !terminator_nohttp
+// +build !terminator_nohttp
+
+package terminator
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})}
+	go srv.Serve(listener)
+
+	var mu sync.Mutex
+	steps := []string{}
+	record := func(step string) {
+		mu.Lock()
+		steps = append(steps, step)
+		mu.Unlock()
+	}
+
+	term, err := NewWebService(srv,
+		WithServiceSignals(os.Interrupt),
+		WithReadinessHook(func() { record("readiness") }),
+		WithPreStopDelay(10*time.Millisecond),
+		WithTelemetryFlush("telemetry", func(ctx context.Context) error {
+			record("telemetry")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	term.Add("database", func(ctx context.Context) error {
+		record("database")
+		return nil
+	})
+
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		body <- string(data)
+	}()
+
+	<-started
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(2 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	if data := <-body; data != "done" {
+		t.Errorf("In-flight request should be drained, got %q", data)
+	}
+
+	expected := []string{"readiness", "database", "telemetry"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(steps) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, steps)
+		return
+	}
+
+	for i := range expected {
+		if steps[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, steps)
+			return
+		}
+	}
+}
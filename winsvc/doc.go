@@ -0,0 +1,8 @@
+// Package winsvc runs a terminator as a Windows service. Its Handler
+// implements the svc.Handler interface of golang.org/x/sys/windows/svc: it
+// starts the termination process on the STOP and SHUTDOWN control requests
+// of the service manager, and reports SERVICE_STOP_PENDING with increasing
+// checkpoints while the resources are closed.
+//
+// The package is only available on Windows.
+package winsvc
@@ -0,0 +1,138 @@
+//go:build windows
+// +build windows
+
+package winsvc
+
+import (
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+	"golang.org/x/sys/windows/svc"
+)
+
+const (
+
+	// defaultPollInterval is the interval at which the progress of the termination is reported.
+	defaultPollInterval = 500 * time.Millisecond
+
+	// defaultWaitHint is the time the service manager waits for the next checkpoint.
+	defaultWaitHint = 10 * time.Second
+)
+
+// Handler implements svc.Handler for a terminator.
+type Handler struct {
+	term         terminator.Terminator
+	pollInterval time.Duration
+	waitHint     time.Duration
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithPollInterval sets the interval at which the progress of the
+// termination is reported to the service manager. It defaults to 500ms.
+func WithPollInterval(interval time.Duration) Option {
+	return func(h *Handler) {
+		h.pollInterval = interval
+	}
+}
+
+// WithWaitHint sets the time the service manager waits for the next
+// checkpoint before it considers the service hung. It should exceed the
+// longest closer timeout, as a checkpoint is reported only when the
+// termination makes progress. It defaults to 10s.
+func WithWaitHint(hint time.Duration) Option {
+	return func(h *Handler) {
+		h.waitHint = hint
+	}
+}
+
+// New returns a Handler running term as a Windows service.
+func New(term terminator.Terminator, opts ...Option) *Handler {
+	h := &Handler{
+		term:         term,
+		pollInterval: defaultPollInterval,
+		waitHint:     defaultWaitHint,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Run runs term as the Windows service with the given name, see svc.Run.
+// It returns once the termination process completed.
+func Run(name string, term terminator.Terminator, opts ...Option) error {
+	return svc.Run(name, New(term, opts...))
+}
+
+// Execute implements svc.Handler. It reports the service as running until a
+// STOP or SHUTDOWN control request is received or the termination is
+// triggered otherwise, then reports SERVICE_STOP_PENDING with a new
+// checkpoint every time the termination makes progress, and returns once it
+// completed. The service specific exit code is the number of resources that
+// failed or timed out.
+func (h *Handler) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	const accepted = svc.AcceptStop | svc.AcceptShutdown
+
+	changes <- svc.Status{State: svc.StartPending}
+	changes <- svc.Status{State: svc.Running, Accepts: accepted}
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+running:
+	for h.term.State() == terminator.StateIdle {
+		select {
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				h.term.Terminate(terminator.ReasonServiceControl)
+				break running
+			}
+		case <-ticker.C:
+		}
+	}
+
+	return h.stop(requests, changes)
+}
+
+// stop reports the progress of the termination until it completes.
+func (h *Handler) stop(requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	status := svc.Status{
+		State:    svc.StopPending,
+		WaitHint: uint32(h.waitHint / time.Millisecond),
+	}
+	changes <- status
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	var last terminator.Status
+	for !h.term.State().IsTerminal() {
+		select {
+		case request := <-requests:
+			if request.Cmd == svc.Interrogate {
+				changes <- status
+			}
+		case <-ticker.C:
+			current := h.term.Status()
+			if current.Closed != last.Closed || current.Progress != last.Progress {
+				last = current
+				status.CheckPoint++
+				changes <- status
+			}
+		}
+	}
+
+	changes <- svc.Status{State: svc.Stopped}
+
+	if result, ok := h.term.Result(); ok && result.FailedOrTimeoutCount > 0 {
+		return true, uint32(result.FailedOrTimeoutCount)
+	}
+	return false, 0
+}
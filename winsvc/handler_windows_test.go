@@ -0,0 +1,79 @@
+//go:build windows
+// +build windows
+
+package winsvc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RohanPoojary/go-terminator"
+	"golang.org/x/sys/windows/svc"
+)
+
+func TestExecute(t *testing.T) {
+	term := terminator.NewTerminator([]os.Signal{os.Interrupt}, terminator.WithRegistrationGrace(0))
+	term.Add("db", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	term.Add("cache", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("flush failed")
+	})
+	term.Ready()
+
+	requests := make(chan svc.ChangeRequest)
+	changes := make(chan svc.Status, 100)
+
+	type exit struct {
+		specific bool
+		code     uint32
+	}
+	done := make(chan exit, 1)
+	go func() {
+		specific, code := New(term, WithPollInterval(10*time.Millisecond)).Execute(nil, requests, changes)
+		done <- exit{specific, code}
+	}()
+
+	requests <- svc.ChangeRequest{Cmd: svc.Stop}
+
+	var got exit
+	select {
+	case got = <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Execute should return once the termination completed")
+	}
+	close(changes)
+
+	if !got.specific || got.code != 1 {
+		t.Errorf("Expected service specific exit code 1, got %v %d", got.specific, got.code)
+	}
+
+	result, _ := term.Result()
+	if result.Reason != terminator.ReasonServiceControl {
+		t.Errorf("Expected reason %q, got %q", terminator.ReasonServiceControl, result.Reason)
+	}
+
+	var states []svc.State
+	var checkpoint uint32
+	for status := range changes {
+		states = append(states, status.State)
+		if status.State == svc.StopPending {
+			if status.CheckPoint < checkpoint {
+				t.Errorf("Checkpoints should increase, got %d after %d", status.CheckPoint, checkpoint)
+			}
+			checkpoint = status.CheckPoint
+		}
+	}
+
+	if len(states) < 4 || states[1] != svc.Running || states[2] != svc.StopPending || states[len(states)-1] != svc.Stopped {
+		t.Errorf("Unexpected status sequence %v", states)
+	}
+	if checkpoint == 0 {
+		t.Error("Expected checkpoints while the closers run")
+	}
+}
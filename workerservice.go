@@ -0,0 +1,55 @@
+package terminator
+
+import "context"
+
+// WithStopIntake adds a closer run in PhaseIntake to stop consuming new messages.
+func WithStopIntake(name string, stop CloseFunc) ServiceOption {
+	return func(c *serviceConfig) {
+		c.closers = append(c.closers, phaseCloser{name: name, close: stop, phase: PhaseIntake})
+	}
+}
+
+// WithCommit adds a closer run in PhaseCommit to commit or acknowledge the handled messages.
+func WithCommit(name string, commit CloseFunc) ServiceOption {
+	return func(c *serviceConfig) {
+		c.closers = append(c.closers, phaseCloser{name: name, close: commit, phase: PhaseCommit})
+	}
+}
+
+// WithBrokerClose adds a closer run in PhaseBroker to close a broker connection.
+func WithBrokerClose(name string, close CloseFunc) ServiceOption {
+	return func(c *serviceConfig) {
+		c.closers = append(c.closers, phaseCloser{name: name, close: close, phase: PhaseBroker})
+	}
+}
+
+// NewWorkerService creates a terminator wired with the typical shutdown
+// pipeline of a queue consumer: stop the intake, drain the in-flight
+// handlers tracked by tracker, commit or acknowledge the handled messages,
+// close the resources registered by the application, close the broker
+// connections and flush telemetry. Further closers can be added to any of
+// these phases with AddWithOptions and InPhase. It returns the error of the
+// registrations of the pipeline, if any.
+func NewWorkerService(tracker *Tracker, opts ...ServiceOption) (Terminator, error) {
+	config := newServiceConfig(opts)
+	term, err := config.newTerminator()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = term.AddWithOptions("in-flight handlers", func(ctx context.Context) error {
+		drainCtx, cancel := context.WithTimeout(ctx, config.drainTimeout)
+		defer cancel()
+
+		return tracker.Drain(drainCtx)
+	}, InPhase(PhaseDrain))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.addClosers(term); err != nil {
+		return nil, err
+	}
+
+	return term, nil
+}
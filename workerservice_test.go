@@ -0,0 +1,69 @@
+package terminator
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerService(t *testing.T) {
+	tracker := NewTracker()
+
+	var mu sync.Mutex
+	steps := []string{}
+	record := func(step string) CloseFunc {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			steps = append(steps, step)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	term, err := NewWorkerService(tracker,
+		WithServiceSignals(os.Interrupt),
+		WithStopIntake("consumer", record("intake")),
+		WithCommit("offsets", record("commit")),
+		WithBrokerClose("broker", record("broker")),
+		WithTelemetryFlush("telemetry", record("telemetry")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	term.Add("cache", record("cache"))
+
+	tracker.Begin()
+	go func() {
+		// Outlives the registration settle window, so the intake is stopped first.
+		time.Sleep(300 * time.Millisecond)
+		mu.Lock()
+		steps = append(steps, "handler")
+		mu.Unlock()
+		tracker.Done()
+	}()
+
+	termInternal := term.(*terminator)
+	termInternal.signalChan <- os.Interrupt
+
+	if !term.Wait(2 * time.Second) {
+		t.Error("Wait shouldn't time out")
+		return
+	}
+
+	expected := []string{"intake", "handler", "commit", "cache", "broker", "telemetry"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(steps) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, steps)
+		return
+	}
+
+	for i := range expected {
+		if steps[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, steps)
+			return
+		}
+	}
+}